@@ -7,15 +7,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/handler"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/mail"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/storage"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 	"github.com/joho/godotenv"
 )
 
@@ -31,6 +37,17 @@ func main() {
 		port = "8088"
 	}
 
+	// JWTの署名鍵の読み込み（JWT_KEYSが未設定の場合はJWT_SECRETによるHS256を使用）
+	keySet, err := util.NewKeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	if keySet.SigningAlgorithm() == util.AlgHS256 {
+		log.Println("Warning: JWT_KEYS is not set, signing tokens with JWT_SECRET (HS256)")
+	} else {
+		log.Printf("JWT signing key: %s", keySet.SigningKeyID())
+	}
+
 	// データベース接続の初期化
 	if err := database.Init(); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -49,16 +66,54 @@ func main() {
 	userRepo := repository.NewUserRepository(db)
 	pinRepo := repository.NewPinRepository(db)
 	connectRepo := repository.NewConnectRepository(db)
+	reviewRepo := repository.NewReviewRepository(db)
+	photoRepo := repository.NewPhotoRepository(db)
+	areaRepo := repository.NewAreaRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	passwordResetTokenRepo := repository.NewPasswordResetTokenRepository(db)
+
+	// 写真ストレージの初期化
+	photoStorage, err := storage.New(storage.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to initialize photo storage: %v", err)
+	}
+
+	// メール送信の初期化
+	mailer, err := mail.New(mail.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+
+	// トークン失効ストアの初期化（認証ミドルウェアと共有する）
+	revocationStore := revocation.NewPostgresStore(db)
+	authMiddleware := middleware.NewAuthMiddleware(keySet, revocationStore)
+
+	// リクエストとは別に実行する処理（メール送信など）はシャットダウン時に完了を待つ
+	backgroundTasks := service.NewBackgroundTasks()
 
 	// サービスの初期化
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, revocationStore, keySet)
+	verificationService := service.NewEmailVerificationService(userRepo, mailer, frontendLink("EMAIL_VERIFY_URL", "/verify-email"), keySet, backgroundTasks)
+	passwordResetService := service.NewPasswordResetService(userRepo, passwordResetTokenRepo, refreshTokenRepo, revocationStore, mailer, frontendLink("PASSWORD_RESET_URL", "/reset-password"), backgroundTasks)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	connectService := service.NewConnectService(connectRepo, pinRepo)
+	reviewService := service.NewReviewService(reviewRepo, pinRepo)
+	photoService := service.NewPhotoService(photoRepo, pinRepo, photoStorage)
+	trashService := service.NewTrashService(pinRepo, photoStorage)
+	areaService := service.NewAreaService(areaRepo, pinRepo, connectRepo)
 
 	// ハンドラーの初期化
-	authHandler := handler.NewAuthHandler(authService)
+	authHandler := handler.NewAuthHandler(authService, verificationService)
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetService)
 	pinHandler := handler.NewPinHandler(pinService)
 	connectHandler := handler.NewConnectHandler(connectService)
+	reviewHandler := handler.NewReviewHandler(reviewService)
+	photoHandler := handler.NewPhotoHandler(photoService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	areaHandler := handler.NewAreaHandler(areaService)
+	exportHandler := handler.NewExportHandler(pinService, connectService)
+	graphHandler := handler.NewGraphHandler(connectService)
+	jwksHandler := handler.NewJWKSHandler(keySet)
 
 	// Chi routerのセットアップ
 	r := chi.NewRouter()
@@ -67,40 +122,92 @@ func main() {
 	r.Use(middleware.LoggerMiddleware)
 	r.Use(middleware.CORSMiddleware)
 
+	// トークン検証用の公開鍵（認証不要）
+	r.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// ルーティング設定
 	r.Route("/api", func(r chi.Router) {
 		// 認証エンドポイント（認証不要）
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/signup", authHandler.SignUp)
 			r.Post("/login", authHandler.Login)
+			r.Post("/refresh", authHandler.Refresh)
+			r.Post("/verify-email", authHandler.VerifyEmail)
+			r.Post("/resend-verification", authHandler.ResendVerification)
+			r.Post("/forgot-password", passwordResetHandler.ForgotPassword)
+			r.Post("/reset-password", passwordResetHandler.ResetPassword)
 			r.Get("/test", authHandler.TestConnection)
 
 			// 認証が必要なエンドポイント
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.AuthMiddleware)
+				r.Use(authMiddleware)
 				r.Post("/logout", authHandler.Logout)
+				r.Post("/logout-all", authHandler.LogoutAll)
 				r.Get("/me", authHandler.GetMe)
 			})
 		})
 
 		// Pinエンドポイント（全て認証が必要）
 		r.Route("/pins", func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware)
+			r.Use(authMiddleware)
 			r.Post("/", pinHandler.CreatePin)
+			r.Post("/import", pinHandler.ImportPins)
 			r.Get("/", pinHandler.GetPins)
+			r.Get("/nearby", pinHandler.SearchNearbyPins)
+			r.Get("/within", pinHandler.GetPinsInBounds)
+			r.Get("/clusters", pinHandler.GetPinClusters)
+			r.Get("/export", exportHandler.ExportPins)
+			r.Get("/trash", trashHandler.GetTrash)
 			r.Get("/{id}", pinHandler.GetPin)
 			r.Put("/{id}", pinHandler.UpdatePin)
+			r.Put("/{id}/visibility", pinHandler.UpdatePinVisibility)
+			r.Get("/{id}/history", pinHandler.GetPinHistory)
+			r.Post("/{id}/revert/{revision}", pinHandler.RevertPin)
 			r.Delete("/{id}", pinHandler.DeletePin)
+			r.Post("/{id}/restore", trashHandler.RestorePin)
+			r.Delete("/{id}/purge", trashHandler.PurgePin)
+			r.Get("/{id}/reviews", reviewHandler.GetReviews)
+			r.Post("/{id}/reviews", reviewHandler.CreateReview)
+			r.Put("/{id}/reviews/{reviewId}", reviewHandler.UpdateReview)
+			r.Delete("/{id}/reviews/{reviewId}", reviewHandler.DeleteReview)
+			r.Get("/{id}/photos", photoHandler.GetPhotos)
+			r.Post("/{id}/photos", photoHandler.UploadPhoto)
+			r.Get("/{id}/photos/{photoId}", photoHandler.GetPhoto)
+			r.Delete("/{id}/photos/{photoId}", photoHandler.DeletePhoto)
+		})
+
+		// 公開エンドポイント（認証不要）
+		r.Route("/public", func(r chi.Router) {
+			r.Get("/pins", pinHandler.GetPublicPins)
+			r.Get("/pins/shared/{token}", pinHandler.GetSharedPin)
+		})
+
+		// タイルエンドポイント（全て認証が必要）
+		r.Route("/tiles", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Get("/pins/{z}/{x}/{y}.mvt", pinHandler.GetPinTile)
 		})
 
 		// Connectエンドポイント（全て認証が必要）
 		r.Route("/connects", func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware)
+			r.Use(authMiddleware)
 			r.Post("/", connectHandler.CreateConnect)
 			r.Get("/", connectHandler.GetConnects)
+			r.Get("/path", connectHandler.GetPath)
+			r.Get("/graph", graphHandler.GetConnectGraph)
 			r.Put("/{id}", connectHandler.UpdateConnect)
 			r.Delete("/{id}", connectHandler.DeleteConnect)
 		})
+
+		// Areaエンドポイント（全て認証が必要）
+		r.Route("/areas", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Post("/", areaHandler.CreateArea)
+			r.Get("/", areaHandler.GetAreas)
+			r.Get("/containing", areaHandler.GetContainingAreas)
+			r.Get("/{id}", areaHandler.GetArea)
+			r.Delete("/{id}", areaHandler.DeleteArea)
+		})
 	})
 
 	// HTTPサーバーの設定
@@ -112,6 +219,15 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// ゴミ箱の保持期間を過ぎたPinを定期的に完全削除
+	retention, err := trashRetention()
+	if err != nil {
+		log.Fatalf("Invalid trash retention: %v", err)
+	}
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go service.RunTrashRetention(jobCtx, trashService, retention, time.Hour)
+
 	// サーバーを別のゴルーチンで起動
 	go func() {
 		log.Printf("うんちんぐすぽっと API server starting on port %s", port)
@@ -126,6 +242,7 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	// シャットダウンのタイムアウト設定（30秒）
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -135,6 +252,34 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	backgroundTasks.Wait()
 
 	log.Println("Server exited gracefully")
 }
+
+// trashRetention は環境変数TRASH_RETENTION_DAYSからゴミ箱の保持期間を取得します
+func trashRetention() (time.Duration, error) {
+	value := os.Getenv("TRASH_RETENTION_DAYS")
+	if value == "" {
+		return service.DefaultTrashRetention, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("TRASH_RETENTION_DAYS must be a positive integer: %q", value)
+	}
+
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// frontendLink はメールに記載するリンクのURLを取得します
+// 環境変数keyが未設定の場合はFRONTEND_URLのpathを使用します
+func frontendLink(key, path string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if frontendURL := os.Getenv("FRONTEND_URL"); frontendURL != "" {
+		return strings.TrimRight(frontendURL, "/") + path
+	}
+	return ""
+}
@@ -152,7 +152,7 @@ func (tdb *TestDB) Teardown() error {
 // CleanupData はテストデータをクリーンアップします（テーブルのデータを削除）
 func (tdb *TestDB) CleanupData() error {
 	// 外部キー制約を考慮して、依存関係の逆順で削除
-	tables := []string{"connect", "pins", "users"}
+	tables := []string{"areas", "pin_revisions", "photos", "reviews", "connect", "pins", "refresh_tokens", "revoked_tokens", "password_reset_tokens", "users"}
 	
 	for _, table := range tables {
 		query := fmt.Sprintf("DELETE FROM %s", table)
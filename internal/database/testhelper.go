@@ -19,8 +19,18 @@ func NewTestHelper(db *TestDB) *TestHelper {
 	return &TestHelper{DB: db}
 }
 
-// CreateTestUser はテスト用のユーザーを作成します
+// CreateTestUser はメールアドレスを確認済みのテスト用のユーザーを作成します
 func (h *TestHelper) CreateTestUser(email, password, name string) (*model.User, error) {
+	return h.createTestUser(email, password, name, true)
+}
+
+// CreateUnverifiedTestUser はメールアドレスが未確認のテスト用のユーザーを作成します
+func (h *TestHelper) CreateUnverifiedTestUser(email, password, name string) (*model.User, error) {
+	return h.createTestUser(email, password, name, false)
+}
+
+// createTestUser はテスト用のユーザーを作成します
+func (h *TestHelper) createTestUser(email, password, name string, verified bool) (*model.User, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
@@ -34,14 +44,17 @@ func (h *TestHelper) CreateTestUser(email, password, name string) (*model.User,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	if verified {
+		user.EmailVerifiedAt = &user.CreatedAt
+	}
 
 	query := `
-		INSERT INTO users (id, email, password, name, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (id, email, password, name, created_at, updated_at, email_verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	_, err = h.DB.DB.ExecContext(context.Background(), query,
-		user.ID, user.Email, user.Password, user.Name, user.CreatedAt, user.UpdatedAt)
+		user.ID, user.Email, user.Password, user.Name, user.CreatedAt, user.UpdatedAt, user.EmailVerifiedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -88,10 +101,11 @@ func (h *TestHelper) CreateTestConnect(userID, pinID1, pinID2 string, show bool)
 	query := `
 		INSERT INTO connect (id, user_id, pins_id_1, pins_id_2, show)
 		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
 	`
 
-	_, err := h.DB.DB.ExecContext(context.Background(), query,
-		connect.ID, connect.UserID, connect.PinID1, connect.PinID2, connect.Show)
+	err := h.DB.DB.QueryRowContext(context.Background(), query,
+		connect.ID, connect.UserID, connect.PinID1, connect.PinID2, connect.Show).Scan(&connect.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +116,7 @@ func (h *TestHelper) CreateTestConnect(userID, pinID1, pinID2 string, show bool)
 // GetUserByID はIDでユーザーを取得します
 func (h *TestHelper) GetUserByID(id string) (*model.User, error) {
 	var user model.User
-	query := `SELECT id, email, password, name, created_at, updated_at, deleted_at FROM users WHERE id = $1`
+	query := `SELECT id, email, password, name, created_at, updated_at, deleted_at, email_verified_at, verification_mail_failed_at FROM users WHERE id = $1`
 	err := h.DB.DB.GetContext(context.Background(), &user, query, id)
 	if err != nil {
 		return nil, err
@@ -132,7 +146,7 @@ func (h *TestHelper) GetPinByID(id string) (*model.Pin, error) {
 // GetConnectByID はIDでConnectを取得します
 func (h *TestHelper) GetConnectByID(id string) (*model.Connect, error) {
 	var connect model.Connect
-	query := `SELECT id, user_id, pins_id_1, pins_id_2, show FROM connect WHERE id = $1`
+	query := `SELECT id, user_id, pins_id_1, pins_id_2, show, created_at FROM connect WHERE id = $1`
 	err := h.DB.DB.GetContext(context.Background(), &connect, query, id)
 	if err != nil {
 		return nil, err
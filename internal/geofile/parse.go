@@ -0,0 +1,266 @@
+// Package geofile はGPX/KML/GeoJSONなどの位置情報ファイルの読み書きを提供します
+package geofile
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format は位置情報ファイルの形式を表します
+type Format string
+
+// 対応しているファイル形式
+const (
+	FormatGPX     Format = "gpx"
+	FormatKML     Format = "kml"
+	FormatGeoJSON Format = "geojson"
+)
+
+var (
+	// ErrUnsupportedFormat は未対応のファイル形式エラー
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	// ErrMalformedFile はファイルの構造が不正なエラー
+	ErrMalformedFile = errors.New("malformed file")
+	// ErrUnsupportedGeometry はPoint以外のジオメトリを表すエラー
+	ErrUnsupportedGeometry = errors.New("unsupported geometry type")
+)
+
+// Point はファイルから読み取った1件の地点を表します
+// Errが設定されている場合、その地点は読み取りに失敗しています
+type Point struct {
+	Index     int // ファイル内での出現順（1始まり）
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Err       error
+}
+
+// ParseFormat は文字列からファイル形式を判定します
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "gpx":
+		return FormatGPX, nil
+	case "kml":
+		return FormatKML, nil
+	case "geojson", "json":
+		return FormatGeoJSON, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// DetectFormat はファイル名の拡張子からファイル形式を判定します
+func DetectFormat(filename string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(filename), "."))
+}
+
+// Parse はファイルを先頭から逐次読み取り、地点ごとにfnを呼び出します
+// ファイル全体をメモリに展開しないため、大きなファイルでも一定のメモリで処理できます
+// fnがエラーを返した場合は読み取りを中断してそのエラーを返します
+func Parse(format Format, r io.Reader, fn func(Point) error) error {
+	switch format {
+	case FormatGPX:
+		return parseGPX(r, fn)
+	case FormatKML:
+		return parseKML(r, fn)
+	case FormatGeoJSON:
+		return parseGeoJSON(r, fn)
+	}
+	return ErrUnsupportedFormat
+}
+
+// gpxWaypoint はGPXのwpt要素を表します
+type gpxWaypoint struct {
+	Lat  string `xml:"lat,attr"`
+	Lon  string `xml:"lon,attr"`
+	Name string `xml:"name"`
+}
+
+// parseGPX はGPXファイルのウェイポイント（wpt）を読み取ります
+func parseGPX(r io.Reader, fn func(Point) error) error {
+	return walkXML(r, "wpt", func(d *xml.Decoder, start *xml.StartElement, index int) error {
+		var wpt gpxWaypoint
+		if err := d.DecodeElement(&wpt, start); err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+		}
+
+		point := Point{Index: index, Name: strings.TrimSpace(wpt.Name)}
+		point.Latitude, point.Longitude, point.Err = parseLatLng(wpt.Lat, wpt.Lon)
+		return fn(point)
+	})
+}
+
+// kmlPlacemark はKMLのPlacemark要素を表します
+type kmlPlacemark struct {
+	Name  string `xml:"name"`
+	Point *struct {
+		Coordinates string `xml:"coordinates"`
+	} `xml:"Point"`
+}
+
+// parseKML はKMLファイルのPlacemarkを読み取ります
+func parseKML(r io.Reader, fn func(Point) error) error {
+	return walkXML(r, "Placemark", func(d *xml.Decoder, start *xml.StartElement, index int) error {
+		var placemark kmlPlacemark
+		if err := d.DecodeElement(&placemark, start); err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+		}
+
+		point := Point{Index: index, Name: strings.TrimSpace(placemark.Name)}
+		if placemark.Point == nil {
+			point.Err = ErrUnsupportedGeometry
+			return fn(point)
+		}
+
+		// KMLの座標は "経度,緯度[,高度]" の形式
+		parts := strings.Split(strings.TrimSpace(placemark.Point.Coordinates), ",")
+		if len(parts) < 2 {
+			point.Err = errors.New("coordinates must be in \"longitude,latitude\" format")
+			return fn(point)
+		}
+		point.Latitude, point.Longitude, point.Err = parseLatLng(parts[1], parts[0])
+		return fn(point)
+	})
+}
+
+// walkXML はXMLを逐次読み取り、指定したローカル名の要素ごとにfnを呼び出します
+func walkXML(r io.Reader, element string, fn func(d *xml.Decoder, start *xml.StartElement, index int) error) error {
+	decoder := xml.NewDecoder(r)
+	index := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != element {
+			continue
+		}
+
+		index++
+		if err := fn(decoder, &start, index); err != nil {
+			return err
+		}
+	}
+}
+
+// geoJSONFeature はGeoJSONのFeatureを表します
+type geoJSONFeature struct {
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// parseGeoJSON はGeoJSON FeatureCollectionのPoint Featureを読み取ります
+// features配列の要素を1件ずつデコードします
+func parseGeoJSON(r io.Reader, fn func(Point) error) error {
+	decoder := json.NewDecoder(r)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	foundFeatures := false
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+		}
+		key, _ := keyToken.(string)
+
+		if key != "features" {
+			// features以外のメンバーは読み飛ばす
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+			}
+			continue
+		}
+
+		foundFeatures = true
+		if err := expectDelim(decoder, '['); err != nil {
+			return err
+		}
+
+		index := 0
+		for decoder.More() {
+			index++
+			var feature geoJSONFeature
+			if err := decoder.Decode(&feature); err != nil {
+				return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+			}
+			if err := fn(featureToPoint(index, &feature)); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(decoder, ']'); err != nil {
+			return err
+		}
+	}
+
+	if !foundFeatures {
+		return fmt.Errorf("%w: GeoJSON must be a FeatureCollection", ErrMalformedFile)
+	}
+	return nil
+}
+
+// featureToPoint はGeoJSON FeatureをPointに変換します
+func featureToPoint(index int, feature *geoJSONFeature) Point {
+	point := Point{Index: index}
+	if name, ok := feature.Properties["name"].(string); ok {
+		point.Name = strings.TrimSpace(name)
+	}
+
+	if feature.Geometry == nil || feature.Geometry.Type != "Point" {
+		point.Err = ErrUnsupportedGeometry
+		return point
+	}
+
+	// GeoJSONの座標は[経度, 緯度(, 高度)]の順序
+	var coordinates []float64
+	if err := json.Unmarshal(feature.Geometry.Coordinates, &coordinates); err != nil || len(coordinates) < 2 {
+		point.Err = errors.New("point coordinates must be [longitude, latitude]")
+		return point
+	}
+	point.Longitude = coordinates[0]
+	point.Latitude = coordinates[1]
+	return point
+}
+
+// expectDelim は次のトークンが指定した区切り文字であることを確認します
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedFile, err)
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("%w: expected %q", ErrMalformedFile, delim)
+	}
+	return nil
+}
+
+// parseLatLng は文字列の緯度経度を数値に変換します
+func parseLatLng(lat, lng string) (float64, float64, error) {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return 0, 0, errors.New("latitude must be a number")
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return 0, 0, errors.New("longitude must be a number")
+	}
+	return latitude, longitude, nil
+}
@@ -0,0 +1,135 @@
+package geofile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collect はファイルをパースして全地点を返します
+func collect(t *testing.T, format Format, body string) []Point {
+	t.Helper()
+	var points []Point
+	err := Parse(format, strings.NewReader(body), func(p Point) error {
+		points = append(points, p)
+		return nil
+	})
+	require.NoError(t, err)
+	return points
+}
+
+func TestParse_GPX(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="35.6812" lon="139.7671"><name>東京駅</name></wpt>
+  <wpt lat="abc" lon="139.7"><name>壊れた地点</name></wpt>
+  <trk><name>トラック</name></trk>
+</gpx>`
+
+	points := collect(t, FormatGPX, body)
+	require.Len(t, points, 2)
+
+	assert.Equal(t, 1, points[0].Index)
+	assert.Equal(t, "東京駅", points[0].Name)
+	assert.Equal(t, 35.6812, points[0].Latitude)
+	assert.Equal(t, 139.7671, points[0].Longitude)
+	assert.NoError(t, points[0].Err)
+
+	assert.Equal(t, 2, points[1].Index)
+	assert.Error(t, points[1].Err)
+}
+
+func TestParse_KML(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>新宿</name>
+        <Point><coordinates>139.7006,35.6896,0</coordinates></Point>
+      </Placemark>
+      <Placemark>
+        <name>路線</name>
+        <LineString><coordinates>139.7,35.6 139.8,35.7</coordinates></LineString>
+      </Placemark>
+    </Folder>
+  </Document>
+</kml>`
+
+	points := collect(t, FormatKML, body)
+	require.Len(t, points, 2)
+
+	assert.Equal(t, "新宿", points[0].Name)
+	assert.Equal(t, 35.6896, points[0].Latitude)
+	assert.Equal(t, 139.7006, points[0].Longitude)
+	assert.NoError(t, points[0].Err)
+
+	assert.True(t, errors.Is(points[1].Err, ErrUnsupportedGeometry))
+}
+
+func TestParse_GeoJSON(t *testing.T) {
+	body := `{
+  "type": "FeatureCollection",
+  "name": "toilets",
+  "features": [
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [135.4959, 34.7025]}, "properties": {"name": "大阪駅"}},
+    {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {}},
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [135.5]}, "properties": {"name": "座標不足"}}
+  ]
+}`
+
+	points := collect(t, FormatGeoJSON, body)
+	require.Len(t, points, 3)
+
+	assert.Equal(t, "大阪駅", points[0].Name)
+	assert.Equal(t, 34.7025, points[0].Latitude)
+	assert.Equal(t, 135.4959, points[0].Longitude)
+	assert.NoError(t, points[0].Err)
+
+	assert.True(t, errors.Is(points[1].Err, ErrUnsupportedGeometry))
+	assert.Error(t, points[2].Err)
+	assert.False(t, errors.Is(points[2].Err, ErrUnsupportedGeometry))
+}
+
+func TestParse_GeoJSONNotFeatureCollection(t *testing.T) {
+	err := Parse(FormatGeoJSON, strings.NewReader(`{"type": "Point", "coordinates": [0, 0]}`), func(Point) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, ErrMalformedFile))
+}
+
+func TestParse_MalformedXML(t *testing.T) {
+	err := Parse(FormatGPX, strings.NewReader(`<gpx><wpt lat="1" lon="2"><name>x</wpt>`), func(Point) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, ErrMalformedFile))
+}
+
+func TestParse_CallbackErrorStopsParsing(t *testing.T) {
+	body := `<gpx><wpt lat="1" lon="1"/><wpt lat="2" lon="2"/></gpx>`
+	stop := errors.New("stop")
+
+	count := 0
+	err := Parse(FormatGPX, strings.NewReader(body), func(Point) error {
+		count++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("toilets.GPX")
+	require.NoError(t, err)
+	assert.Equal(t, FormatGPX, format)
+
+	format, err = DetectFormat("export.geojson")
+	require.NoError(t, err)
+	assert.Equal(t, FormatGeoJSON, format)
+
+	_, err = DetectFormat("toilets.csv")
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
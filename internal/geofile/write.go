@@ -0,0 +1,316 @@
+package geofile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// FormatCSV はCSV形式（エクスポートのみ対応）
+const FormatCSV Format = "csv"
+
+// Writer はPinとConnectを位置情報ファイルとして逐次書き出します
+// WritePinを全て呼び出した後にWriteConnectを呼び出し、最後にCloseを呼び出してください
+// （GPXではウェイポイントをルートより前に記述する必要があるため）
+type Writer interface {
+	WritePin(pin *model.Pin) error
+	WriteConnect(line *model.ConnectLine) error
+	Close() error
+}
+
+// NewWriter は指定形式のWriterを作成し、ファイルの先頭部分を書き出します
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	buffered := bufio.NewWriter(w)
+
+	var writer Writer
+	var err error
+	switch format {
+	case FormatGPX:
+		writer, err = newGPXWriter(buffered)
+	case FormatKML:
+		writer, err = newKMLWriter(buffered)
+	case FormatCSV:
+		writer, err = newCSVWriter(buffered)
+	case FormatGeoJSON:
+		writer, err = newGeoJSONWriter(buffered)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// ParseExportFormat はエクスポート形式を判定します
+func ParseExportFormat(s string) (Format, error) {
+	if strings.EqualFold(strings.TrimSpace(s), string(FormatCSV)) {
+		return FormatCSV, nil
+	}
+	return ParseFormat(s)
+}
+
+// ContentType はファイル形式のメディアタイプを返します
+func ContentType(format Format) string {
+	switch format {
+	case FormatGPX:
+		return "application/gpx+xml"
+	case FormatKML:
+		return "application/vnd.google-earth.kml+xml"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatGeoJSON:
+		return "application/geo+json"
+	}
+	return "application/octet-stream"
+}
+
+// Extension はファイル形式の拡張子を返します
+func Extension(format Format) string {
+	return "." + string(format)
+}
+
+// gpxWriter はGPX 1.1形式のWriter
+// Pinはウェイポイント（wpt）、Connectは2点のルート（rte）として書き出す
+type gpxWriter struct {
+	out *bufio.Writer
+	enc *xml.Encoder
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Time string  `xml:"time,omitempty"`
+	Name string  `xml:"name,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string     `xml:"name"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+func newGPXWriter(out *bufio.Writer) (*gpxWriter, error) {
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return nil, err
+	}
+	enc := xml.NewEncoder(out)
+	err := enc.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "gpx"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "version"}, Value: "1.1"},
+			{Name: xml.Name{Local: "creator"}, Value: "UnchingSpot"},
+			{Name: xml.Name{Local: "xmlns"}, Value: "http://www.topografix.com/GPX/1/1"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gpxWriter{out: out, enc: enc}, nil
+}
+
+func (g *gpxWriter) WritePin(pin *model.Pin) error {
+	return g.enc.EncodeElement(gpxPoint{
+		Lat:  pin.Latitude,
+		Lon:  pin.Longitude,
+		Time: pin.CreatedAt.UTC().Format(time.RFC3339),
+		Name: pin.Name,
+	}, xml.StartElement{Name: xml.Name{Local: "wpt"}})
+}
+
+func (g *gpxWriter) WriteConnect(line *model.ConnectLine) error {
+	return g.enc.EncodeElement(gpxRoute{
+		Name: line.ID,
+		Points: []gpxPoint{
+			{Lat: line.From.Latitude, Lon: line.From.Longitude},
+			{Lat: line.To.Latitude, Lon: line.To.Longitude},
+		},
+	}, xml.StartElement{Name: xml.Name{Local: "rte"}})
+}
+
+func (g *gpxWriter) Close() error {
+	if err := g.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "gpx"}}); err != nil {
+		return err
+	}
+	if err := g.enc.Flush(); err != nil {
+		return err
+	}
+	return g.out.Flush()
+}
+
+// kmlWriter はKML 2.2形式のWriter
+// PinはPointのPlacemark、ConnectはLineStringのPlacemarkとして書き出す
+type kmlWriter struct {
+	out *bufio.Writer
+	enc *xml.Encoder
+}
+
+type kmlPlacemarkOut struct {
+	Name       string  `xml:"name"`
+	Point      *kmlGeo `xml:"Point,omitempty"`
+	LineString *kmlGeo `xml:"LineString,omitempty"`
+}
+
+type kmlGeo struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+func newKMLWriter(out *bufio.Writer) (*kmlWriter, error) {
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return nil, err
+	}
+	enc := xml.NewEncoder(out)
+	err := enc.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "kml"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: "http://www.opengis.net/kml/2.2"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Document"}}); err != nil {
+		return nil, err
+	}
+	return &kmlWriter{out: out, enc: enc}, nil
+}
+
+func (k *kmlWriter) WritePin(pin *model.Pin) error {
+	return k.enc.EncodeElement(kmlPlacemarkOut{
+		Name:  pin.Name,
+		Point: &kmlGeo{Coordinates: kmlCoordinate(pin.Latitude, pin.Longitude)},
+	}, xml.StartElement{Name: xml.Name{Local: "Placemark"}})
+}
+
+func (k *kmlWriter) WriteConnect(line *model.ConnectLine) error {
+	return k.enc.EncodeElement(kmlPlacemarkOut{
+		Name: line.ID,
+		LineString: &kmlGeo{Coordinates: kmlCoordinate(line.From.Latitude, line.From.Longitude) +
+			" " + kmlCoordinate(line.To.Latitude, line.To.Longitude)},
+	}, xml.StartElement{Name: xml.Name{Local: "Placemark"}})
+}
+
+func (k *kmlWriter) Close() error {
+	if err := k.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Document"}}); err != nil {
+		return err
+	}
+	if err := k.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "kml"}}); err != nil {
+		return err
+	}
+	if err := k.enc.Flush(); err != nil {
+		return err
+	}
+	return k.out.Flush()
+}
+
+// kmlCoordinate はKMLの "経度,緯度" 形式の座標文字列を返します
+func kmlCoordinate(lat, lng float64) string {
+	return formatFloat(lng) + "," + formatFloat(lat)
+}
+
+// csvWriter はCSV形式のWriter
+// CSVは線を表現できないためConnectは書き出さない
+type csvWriter struct {
+	out *bufio.Writer
+	csv *csv.Writer
+}
+
+// csvHeader はCSVのヘッダー行
+var csvHeader = []string{"id", "name", "latitude", "longitude", "created_at", "edited_at"}
+
+// csvFormulaPrefixes は表計算ソフトで数式として解釈されるセルの先頭文字
+const csvFormulaPrefixes = "=+-@\t\r"
+
+func newCSVWriter(out *bufio.Writer) (*csvWriter, error) {
+	w := csv.NewWriter(out)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return &csvWriter{out: out, csv: w}, nil
+}
+
+func (c *csvWriter) WritePin(pin *model.Pin) error {
+	return c.csv.Write([]string{
+		pin.ID,
+		csvText(pin.Name),
+		formatFloat(pin.Latitude),
+		formatFloat(pin.Longitude),
+		pin.CreatedAt.UTC().Format(time.RFC3339),
+		pin.EditedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// csvText はユーザーが入力した文字列をCSVのセルとして書き出せるようにします
+// 数式として実行されないよう（CSVインジェクション）、数式の先頭文字で始まる場合は ' を付けます
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune(csvFormulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (c *csvWriter) WriteConnect(*model.ConnectLine) error {
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return err
+	}
+	return c.out.Flush()
+}
+
+// geoJSONWriter はGeoJSON FeatureCollection形式のWriter
+// Featureを1件ずつfeatures配列に書き出す
+type geoJSONWriter struct {
+	out   *bufio.Writer
+	count int
+}
+
+func newGeoJSONWriter(out *bufio.Writer) (*geoJSONWriter, error) {
+	if _, err := io.WriteString(out, `{"type":"FeatureCollection","features":[`); err != nil {
+		return nil, err
+	}
+	return &geoJSONWriter{out: out}, nil
+}
+
+func (g *geoJSONWriter) WritePin(pin *model.Pin) error {
+	return g.writeFeature(model.NewPinFeature(pin))
+}
+
+func (g *geoJSONWriter) WriteConnect(line *model.ConnectLine) error {
+	return g.writeFeature(model.NewConnectLineFeature(line))
+}
+
+func (g *geoJSONWriter) writeFeature(feature *model.Feature) error {
+	body, err := json.Marshal(feature)
+	if err != nil {
+		return fmt.Errorf("failed to encode feature: %w", err)
+	}
+	if g.count > 0 {
+		if err := g.out.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	g.count++
+	_, err = g.out.Write(body)
+	return err
+}
+
+func (g *geoJSONWriter) Close() error {
+	if _, err := io.WriteString(g.out, "]}\n"); err != nil {
+		return err
+	}
+	return g.out.Flush()
+}
+
+// formatFloat は座標を最短の10進表記に変換します
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
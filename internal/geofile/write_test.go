@@ -0,0 +1,154 @@
+package geofile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// export はPinとConnectを指定形式で書き出した結果を返します
+func export(t *testing.T, format Format, pins []*model.Pin, lines []*model.ConnectLine) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	for _, pin := range pins {
+		require.NoError(t, w.WritePin(pin))
+	}
+	for _, line := range lines {
+		require.NoError(t, w.WriteConnect(line))
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+func testExportData() ([]*model.Pin, []*model.ConnectLine) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pins := []*model.Pin{
+		{ID: "pin-1", Name: "東京駅", Latitude: 35.6812, Longitude: 139.7671, CreatedAt: now, EditedAt: now},
+		{ID: "pin-2", Name: "A & B <新宿>", Latitude: 35.6896, Longitude: 139.7006, CreatedAt: now, EditedAt: now},
+	}
+	lines := []*model.ConnectLine{
+		{
+			Connect: &model.Connect{ID: "connect-1", PinID1: "pin-1", PinID2: "pin-2", Show: true},
+			From:    model.LatLng{Latitude: 35.6812, Longitude: 139.7671},
+			To:      model.LatLng{Latitude: 35.6896, Longitude: 139.7006},
+		},
+	}
+	return pins, lines
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	pins, lines := testExportData()
+
+	for _, format := range []Format{FormatGPX, FormatKML, FormatGeoJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			body := export(t, format, pins, lines)
+
+			// 書き出したPinがインポート時のパーサーで読み戻せること
+			var points []Point
+			err := Parse(format, strings.NewReader(body), func(p Point) error {
+				if p.Err == nil {
+					points = append(points, p)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, points, 2)
+			assert.Equal(t, "東京駅", points[0].Name)
+			assert.Equal(t, 35.6812, points[0].Latitude)
+			assert.Equal(t, 139.7671, points[0].Longitude)
+			assert.Equal(t, "A & B <新宿>", points[1].Name)
+		})
+	}
+}
+
+func TestWriter_GPXRoute(t *testing.T) {
+	pins, lines := testExportData()
+	body := export(t, FormatGPX, pins, lines)
+
+	assert.Contains(t, body, `<rte><name>connect-1</name><rtept lat="35.6812" lon="139.7671"></rtept><rtept lat="35.6896" lon="139.7006"></rtept></rte>`)
+	// ウェイポイントはルートより前に出力される
+	assert.Less(t, strings.LastIndex(body, "<wpt"), strings.Index(body, "<rte"))
+}
+
+func TestWriter_KMLLineString(t *testing.T) {
+	pins, lines := testExportData()
+	body := export(t, FormatKML, pins, lines)
+
+	assert.Contains(t, body, `<LineString><coordinates>139.7671,35.6812 139.7006,35.6896</coordinates></LineString>`)
+}
+
+func TestWriter_GeoJSON(t *testing.T) {
+	pins, lines := testExportData()
+	body := export(t, FormatGeoJSON, pins, lines)
+
+	var fc model.FeatureCollection
+	require.NoError(t, json.Unmarshal([]byte(body), &fc))
+	assert.Equal(t, model.GeoJSONTypeFeatureCollection, fc.Type)
+	require.Len(t, fc.Features, 3)
+	assert.Equal(t, model.GeoJSONTypeLineString, fc.Features[2].Geometry.Type)
+}
+
+func TestWriter_GeoJSONEmpty(t *testing.T) {
+	body := export(t, FormatGeoJSON, nil, nil)
+
+	var fc model.FeatureCollection
+	require.NoError(t, json.Unmarshal([]byte(body), &fc))
+	assert.Empty(t, fc.Features)
+}
+
+func TestWriter_CSV(t *testing.T) {
+	pins, lines := testExportData()
+	body := export(t, FormatCSV, pins, lines)
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	// ヘッダー + Pin2件（Connectは出力されない）
+	require.Len(t, records, 3)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{"pin-1", "東京駅", "35.6812", "139.7671", "2024-01-02T03:04:05Z", "2024-01-02T03:04:05Z"}, records[1])
+}
+
+func TestWriter_CSVFormulaInjection(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var pins []*model.Pin
+	for _, name := range []string{"=HYPERLINK(\"http://example.com\")", "+1", "-1", "@SUM(A1)", "東京-駅"} {
+		pins = append(pins, &model.Pin{ID: "pin", Name: name, CreatedAt: now, EditedAt: now})
+	}
+	body := export(t, FormatCSV, pins, nil)
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", records[1][1])
+	assert.Equal(t, "'+1", records[2][1])
+	assert.Equal(t, "'-1", records[3][1])
+	assert.Equal(t, "'@SUM(A1)", records[4][1])
+	assert.Equal(t, "東京-駅", records[5][1])
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter(Format("shp"), &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := ParseExportFormat("csv")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = ParseExportFormat("KML")
+	require.NoError(t, err)
+	assert.Equal(t, FormatKML, format)
+
+	_, err = ParseExportFormat("")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,132 @@
+package graph
+
+// Cycle は閉路を表します
+// Edges[i]はNodes[i]とNodes[i+1]を結び、最後の辺は終点から始点に戻ります
+type Cycle struct {
+	Nodes []string
+	Edges []string
+}
+
+// Components は連結成分ごとの頂点を返します
+// 連結成分は最初の頂点を追加した順、各連結成分の頂点は追加した順に並べます
+func (g *Graph) Components() [][]string {
+	index := make(map[string]int, len(g.nodes))
+	count := 0
+	for _, start := range g.nodes {
+		if _, ok := index[start]; ok {
+			continue
+		}
+
+		// 幅優先探索で同じ連結成分の頂点に番号を付ける
+		index[start] = count
+		queue := []string{start}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, e := range g.adjacent[current] {
+				if _, ok := index[e.to]; !ok {
+					index[e.to] = count
+					queue = append(queue, e.to)
+				}
+			}
+		}
+		count++
+	}
+
+	components := make([][]string, count)
+	for _, id := range g.nodes {
+		components[index[id]] = append(components[index[id]], id)
+	}
+
+	return components
+}
+
+// IsolatedNodes は辺のない頂点を追加した順に返します
+func (g *Graph) IsolatedNodes() []string {
+	isolated := []string{}
+	for _, id := range g.nodes {
+		if len(g.adjacent[id]) == 0 {
+			isolated = append(isolated, id)
+		}
+	}
+	return isolated
+}
+
+// Cycles はグラフの基本閉路（全域森に含まれない辺ごとに1つの閉路）を返します
+// 返す閉路の数は「辺の数 - 頂点の数 + 連結成分の数」で、全ての閉路はこれらの組み合わせで表せます
+// 閉路は全域森に含まれない辺を追加した順に並べます
+func (g *Graph) Cycles() []Cycle {
+	type parent struct {
+		node string
+		edge string
+	}
+
+	// 幅優先探索で全域森を作り、各頂点の親と根からの深さを記録する
+	parents := make(map[string]parent, len(g.nodes))
+	depth := make(map[string]int, len(g.nodes))
+	treeEdges := make(map[string]bool, len(g.nodes))
+	for _, root := range g.nodes {
+		if _, ok := depth[root]; ok {
+			continue
+		}
+		depth[root] = 0
+		queue := []string{root}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, e := range g.adjacent[current] {
+				if _, ok := depth[e.to]; ok {
+					continue
+				}
+				depth[e.to] = depth[current] + 1
+				parents[e.to] = parent{node: current, edge: e.id}
+				treeEdges[e.id] = true
+				queue = append(queue, e.to)
+			}
+		}
+	}
+
+	cycles := []Cycle{}
+	for _, e := range g.edges {
+		if treeEdges[e.ID] {
+			continue
+		}
+
+		// 両端から共通の祖先まで木を遡る
+		var fromSide, toSide []parent
+		u, v := e.From, e.To
+		for depth[u] > depth[v] {
+			fromSide = append(fromSide, parent{node: u, edge: parents[u].edge})
+			u = parents[u].node
+		}
+		for depth[v] > depth[u] {
+			toSide = append(toSide, parent{node: v, edge: parents[v].edge})
+			v = parents[v].node
+		}
+		for u != v {
+			fromSide = append(fromSide, parent{node: u, edge: parents[u].edge})
+			toSide = append(toSide, parent{node: v, edge: parents[v].edge})
+			u, v = parents[u].node, parents[v].node
+		}
+
+		// From → 共通の祖先 → To の順に並べ、最後に追加した辺で始点に戻る
+		cycle := Cycle{
+			Nodes: make([]string, 0, len(fromSide)+len(toSide)+1),
+			Edges: make([]string, 0, len(fromSide)+len(toSide)+1),
+		}
+		for _, p := range fromSide {
+			cycle.Nodes = append(cycle.Nodes, p.node)
+			cycle.Edges = append(cycle.Edges, p.edge)
+		}
+		cycle.Nodes = append(cycle.Nodes, u)
+		for i := len(toSide) - 1; i >= 0; i-- {
+			cycle.Edges = append(cycle.Edges, toSide[i].edge)
+			cycle.Nodes = append(cycle.Nodes, toSide[i].node)
+		}
+		cycle.Edges = append(cycle.Edges, e.ID)
+
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGraph は三角形a-b-c、aから伸びる枝d、線分e-f、孤立した頂点gのグラフを作成します
+func newTestGraph() *Graph {
+	g := New()
+	g.AddEdge("ab", "a", "b", 1)
+	g.AddEdge("bc", "b", "c", 1)
+	g.AddEdge("ca", "c", "a", 1)
+	g.AddEdge("ad", "a", "d", 1)
+	g.AddEdge("ef", "e", "f", 1)
+	g.AddNode("g")
+	return g
+}
+
+func TestGraph_Degree(t *testing.T) {
+	g := newTestGraph()
+
+	assert.Equal(t, 3, g.Degree("a"))
+	assert.Equal(t, 2, g.Degree("b"))
+	assert.Equal(t, 1, g.Degree("d"))
+	assert.Equal(t, 0, g.Degree("g"))
+	assert.Equal(t, 0, g.Degree("x"))
+
+	// 自己ループは2として数える
+	g.AddEdge("gg", "g", "g", 1)
+	assert.Equal(t, 2, g.Degree("g"))
+}
+
+func TestGraph_Components(t *testing.T) {
+	g := newTestGraph()
+
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}, {"e", "f"}, {"g"}}, g.Components())
+	assert.Empty(t, New().Components())
+}
+
+func TestGraph_IsolatedNodes(t *testing.T) {
+	g := newTestGraph()
+
+	assert.Equal(t, []string{"g"}, g.IsolatedNodes())
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	t.Run("三角形", func(t *testing.T) {
+		cycles := newTestGraph().Cycles()
+		// 全域木に含まれない辺b-cの両端からaを経由して戻る
+		require.Len(t, cycles, 1)
+		assert.Equal(t, []string{"b", "a", "c"}, cycles[0].Nodes)
+		assert.Equal(t, []string{"ab", "ca", "bc"}, cycles[0].Edges)
+	})
+
+	t.Run("閉路なし", func(t *testing.T) {
+		g := New()
+		g.AddEdge("ab", "a", "b", 1)
+		g.AddEdge("bc", "b", "c", 1)
+		assert.Empty(t, g.Cycles())
+	})
+
+	t.Run("2つの四角形が辺を共有", func(t *testing.T) {
+		// a-b-c-d-a と b-e-f-c-b（辺b-cを共有）
+		g := New()
+		g.AddEdge("ab", "a", "b", 1)
+		g.AddEdge("bc", "b", "c", 1)
+		g.AddEdge("cd", "c", "d", 1)
+		g.AddEdge("da", "d", "a", 1)
+		g.AddEdge("be", "b", "e", 1)
+		g.AddEdge("ef", "e", "f", 1)
+		g.AddEdge("fc", "f", "c", 1)
+
+		cycles := g.Cycles()
+		// 辺7 - 頂点6 + 連結成分1
+		require.Len(t, cycles, 2)
+		for _, c := range cycles {
+			assert.Len(t, c.Nodes, 4)
+			assert.Len(t, c.Edges, 4)
+			assertClosedWalk(t, g, c)
+		}
+	})
+
+	t.Run("多重辺と自己ループ", func(t *testing.T) {
+		g := New()
+		g.AddEdge("ab1", "a", "b", 1)
+		g.AddEdge("ab2", "a", "b", 1)
+		g.AddEdge("bb", "b", "b", 1)
+
+		cycles := g.Cycles()
+		require.Len(t, cycles, 2)
+		assert.Equal(t, []string{"a", "b"}, cycles[0].Nodes)
+		assert.Equal(t, []string{"ab1", "ab2"}, cycles[0].Edges)
+		assert.Equal(t, []string{"b"}, cycles[1].Nodes)
+		assert.Equal(t, []string{"bb"}, cycles[1].Edges)
+	})
+}
+
+// assertClosedWalk は閉路の各辺が隣り合う頂点を結んでいることを確認します
+func assertClosedWalk(t *testing.T, g *Graph, c Cycle) {
+	t.Helper()
+
+	edges := make(map[string]Edge, len(g.edges))
+	for _, e := range g.edges {
+		edges[e.ID] = e
+	}
+	for i, id := range c.Edges {
+		e := edges[id]
+		u, v := c.Nodes[i], c.Nodes[(i+1)%len(c.Nodes)]
+		assert.True(t, (e.From == u && e.To == v) || (e.From == v && e.To == u), "edge %s does not join %s and %s", id, u, v)
+	}
+}
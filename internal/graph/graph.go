@@ -0,0 +1,78 @@
+// Package graph はPinを頂点、Connectを辺とする無向グラフのアルゴリズムを提供します
+package graph
+
+// Graph は重み付きの無向グラフを表します
+// 頂点と辺は文字列のIDで識別し、同じ頂点の組に複数の辺があっても構いません
+// 辺のIDはグラフ内で一意である必要があります
+type Graph struct {
+	nodes    []string
+	edges    []Edge
+	adjacent map[string][]halfEdge
+}
+
+// Edge は頂点FromとToを結ぶ辺を表します
+type Edge struct {
+	ID     string
+	From   string
+	To     string
+	Weight float64
+}
+
+// halfEdge は頂点から出る辺を表します
+type halfEdge struct {
+	id     string
+	to     string
+	weight float64
+}
+
+// New は空のグラフを作成します
+func New() *Graph {
+	return &Graph{adjacent: make(map[string][]halfEdge)}
+}
+
+// AddNode は頂点を追加します
+// 既に存在する頂点の場合は何もしません
+func (g *Graph) AddNode(id string) {
+	if _, ok := g.adjacent[id]; ok {
+		return
+	}
+	g.nodes = append(g.nodes, id)
+	g.adjacent[id] = nil
+}
+
+// AddEdge は頂点fromとtoを結ぶ重みweightの辺を追加します
+// 存在しない頂点は自動的に追加されます
+func (g *Graph) AddEdge(id, from, to string, weight float64) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges = append(g.edges, Edge{ID: id, From: from, To: to, Weight: weight})
+	g.adjacent[from] = append(g.adjacent[from], halfEdge{id: id, to: to, weight: weight})
+	if from != to {
+		g.adjacent[to] = append(g.adjacent[to], halfEdge{id: id, to: from, weight: weight})
+	}
+}
+
+// HasNode は頂点が存在するかを返します
+func (g *Graph) HasNode(id string) bool {
+	_, ok := g.adjacent[id]
+	return ok
+}
+
+// Nodes は頂点を追加した順に返します
+func (g *Graph) Nodes() []string {
+	return g.nodes
+}
+
+// Degree は頂点の次数（接続している辺の数）を返します
+// 自己ループは2として数えます
+func (g *Graph) Degree(id string) int {
+	degree := 0
+	for _, e := range g.adjacent[id] {
+		if e.to == id {
+			degree += 2
+		} else {
+			degree++
+		}
+	}
+	return degree
+}
@@ -0,0 +1,99 @@
+package graph
+
+import "container/heap"
+
+// Path は2つの頂点間の経路を表します
+type Path struct {
+	Nodes  []string // 始点から終点までの頂点（始点と終点を含む）
+	Edges  []string // 経路を構成する辺（Nodesの隣り合う頂点を結ぶ順）
+	Weight float64  // 辺の重みの合計
+}
+
+// ShortestPath はダイクストラ法で頂点fromからtoへの重みが最小の経路を求めます
+// 辺の重みは0以上である必要があります
+// どちらかの頂点が存在しない場合や、経路が存在しない場合はfalseを返します
+func (g *Graph) ShortestPath(from, to string) (*Path, bool) {
+	if !g.HasNode(from) || !g.HasNode(to) {
+		return nil, false
+	}
+
+	type step struct {
+		node string
+		edge string
+	}
+
+	dist := map[string]float64{from: 0}
+	prev := make(map[string]step)
+	done := make(map[string]bool)
+	queue := &priorityQueue{{node: from}}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queueItem)
+		if done[current.node] {
+			continue
+		}
+		done[current.node] = true
+		if current.node == to {
+			break
+		}
+
+		for _, e := range g.adjacent[current.node] {
+			if done[e.to] {
+				continue
+			}
+			d := current.dist + e.weight
+			if old, ok := dist[e.to]; ok && old <= d {
+				continue
+			}
+			dist[e.to] = d
+			prev[e.to] = step{node: current.node, edge: e.id}
+			heap.Push(queue, queueItem{node: e.to, dist: d})
+		}
+	}
+
+	if !done[to] {
+		return nil, false
+	}
+
+	// 終点から始点へ辿って経路を復元する
+	path := &Path{Nodes: []string{to}, Edges: []string{}, Weight: dist[to]}
+	for node := to; node != from; {
+		s := prev[node]
+		path.Nodes = append(path.Nodes, s.node)
+		path.Edges = append(path.Edges, s.edge)
+		node = s.node
+	}
+	reverse(path.Nodes)
+	reverse(path.Edges)
+
+	return path, true
+}
+
+// queueItem は優先度付きキューの要素を表します
+type queueItem struct {
+	node string
+	dist float64
+}
+
+// priorityQueue は始点からの距離が短い順に頂点を取り出す優先度付きキューです
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int           { return len(q) }
+func (q priorityQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q priorityQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x any) { *q = append(*q, x.(queueItem)) }
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// reverse はスライスの順序を逆にします
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
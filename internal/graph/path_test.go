@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortestPath(t *testing.T) {
+	// a - b - d は合計3、a - c - d は合計5、a - d は直接10
+	g := New()
+	g.AddEdge("ab", "a", "b", 1)
+	g.AddEdge("bd", "b", "d", 2)
+	g.AddEdge("ac", "a", "c", 2)
+	g.AddEdge("cd", "c", "d", 3)
+	g.AddEdge("ad", "a", "d", 10)
+
+	path, ok := g.ShortestPath("a", "d")
+	require.True(t, ok)
+	assert.Equal(t, []string{"a", "b", "d"}, path.Nodes)
+	assert.Equal(t, []string{"ab", "bd"}, path.Edges)
+	assert.Equal(t, 3.0, path.Weight)
+
+	// 無向グラフなので逆向きにも辿れる
+	path, ok = g.ShortestPath("d", "a")
+	require.True(t, ok)
+	assert.Equal(t, []string{"d", "b", "a"}, path.Nodes)
+	assert.Equal(t, []string{"bd", "ab"}, path.Edges)
+	assert.Equal(t, 3.0, path.Weight)
+}
+
+func TestShortestPath_SameNode(t *testing.T) {
+	g := New()
+	g.AddNode("a")
+
+	path, ok := g.ShortestPath("a", "a")
+	require.True(t, ok)
+	assert.Equal(t, []string{"a"}, path.Nodes)
+	assert.Empty(t, path.Edges)
+	assert.Equal(t, 0.0, path.Weight)
+}
+
+func TestShortestPath_Disconnected(t *testing.T) {
+	g := New()
+	g.AddEdge("ab", "a", "b", 1)
+	g.AddEdge("cd", "c", "d", 1)
+
+	_, ok := g.ShortestPath("a", "d")
+	assert.False(t, ok)
+
+	// 存在しない頂点
+	_, ok = g.ShortestPath("a", "x")
+	assert.False(t, ok)
+}
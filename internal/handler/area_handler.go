@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// AreaHandler はArea関連のHTTPハンドラーを提供します
+type AreaHandler struct {
+	areaService service.AreaService
+}
+
+// NewAreaHandler は新しいAreaHandlerインスタンスを作成します
+func NewAreaHandler(areaService service.AreaService) *AreaHandler {
+	return &AreaHandler{
+		areaService: areaService,
+	}
+}
+
+// CreateArea はAreaを作成します
+// POST /api/areas
+// pin_idsで環を構成するPinを順序通りに指定するか、connect_idsで閉路になるConnectを指定します
+func (h *AreaHandler) CreateArea(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// リクエストボディのパース
+	var req model.CreateAreaRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateRequired(req.Name, "name"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	if err := util.ValidateMaxLength(req.Name, model.MaxAreaNameLength, "name"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	if (len(req.PinIDs) == 0) == (len(req.ConnectIDs) == 0) {
+		util.RespondValidationError(w, "Exactly one of pin_ids or connect_ids is required")
+		return
+	}
+
+	// Area作成処理
+	var area *model.Area
+	var err error
+	if len(req.PinIDs) > 0 {
+		area, err = h.areaService.CreateArea(r.Context(), userID, req.Name, req.PinIDs)
+	} else {
+		area, err = h.areaService.CreateAreaFromConnects(r.Context(), userID, req.Name, req.ConnectIDs)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAreaPins) {
+			util.RespondValidationError(w, fmt.Sprintf("An area requires %d to %d distinct pins", model.MinAreaPins, model.MaxAreaPins))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidAreaRing) {
+			util.RespondValidationError(w, "Pins must form a simple polygon without self-intersections")
+			return
+		}
+		if errors.Is(err, service.ErrConnectsNotCycle) {
+			util.RespondValidationError(w, "Connects must form a single closed cycle")
+			return
+		}
+		if errors.Is(err, service.ErrPinNotExist) {
+			util.RespondNotFound(w, "One or more pins do not exist")
+			return
+		}
+		if errors.Is(err, service.ErrPinNotOwned) {
+			util.RespondError(w, http.StatusForbidden, util.ErrCodePinNotOwned, "Areas can only be built from your own pins")
+			return
+		}
+		if errors.Is(err, service.ErrConnectNotFound) {
+			util.RespondNotFound(w, "Connect not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedConnectAccess) {
+			util.RespondForbidden(w, "You don't have permission to use this connect")
+			return
+		}
+		util.RespondInternalError(w, "Failed to create area")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusCreated, area)
+}
+
+// GetAreas はユーザーのArea一覧を新しい順に取得します
+// GET /api/areas?limit=&cursor=
+// レスポンスのnext_cursorをcursorに指定すると次のページを取得できます
+func (h *AreaHandler) GetAreas(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	page, err := h.areaService.GetAreaPageByUser(r.Context(), userID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get areas")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// GetContainingAreas はPinまたは地点を含むユーザーのAreaを取得します
+// GET /api/areas/containing?pin_id= または GET /api/areas/containing?lat=&lng=
+func (h *AreaHandler) GetContainingAreas(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	var areas []*model.Area
+	var err error
+	if pinID := r.URL.Query().Get("pin_id"); pinID != "" {
+		areas, err = h.areaService.GetAreasContainingPin(r.Context(), userID, pinID)
+	} else {
+		// クエリパラメータのパース
+		lat, parseErr := util.ParseFloatQuery(r, "lat")
+		if parseErr != nil {
+			util.RespondValidationError(w, parseErr.Error())
+			return
+		}
+		lng, parseErr := util.ParseFloatQuery(r, "lng")
+		if parseErr != nil {
+			util.RespondValidationError(w, parseErr.Error())
+			return
+		}
+		if err := util.ValidateCoordinates(lat, lng); err != nil {
+			util.RespondValidationError(w, err.Error())
+			return
+		}
+		areas, err = h.areaService.GetAreasContainingPoint(r.Context(), userID, lat, lng)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCoordinates) {
+			util.RespondValidationError(w, "Invalid coordinates")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get areas")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, areas)
+}
+
+// GetArea はAreaを取得します
+// GET /api/areas/:id
+func (h *AreaHandler) GetArea(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからArea IDを取得
+	areaID := chi.URLParam(r, "id")
+	if areaID == "" {
+		util.RespondValidationError(w, "Area ID is required")
+		return
+	}
+
+	area, err := h.areaService.GetArea(r.Context(), areaID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrAreaNotFound) {
+			util.RespondNotFound(w, "Area not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedAreaAccess) {
+			util.RespondForbidden(w, "You don't have permission to access this area")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get area")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, area)
+}
+
+// DeleteArea はAreaを削除します
+// DELETE /api/areas/:id
+// Areaを削除しても環を構成するPinやConnectは削除されません
+func (h *AreaHandler) DeleteArea(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからArea IDを取得
+	areaID := chi.URLParam(r, "id")
+	if areaID == "" {
+		util.RespondValidationError(w, "Area ID is required")
+		return
+	}
+
+	err := h.areaService.DeleteArea(r.Context(), areaID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrAreaNotFound) {
+			util.RespondNotFound(w, "Area not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedAreaAccess) {
+			util.RespondForbidden(w, "You don't have permission to delete this area")
+			return
+		}
+		util.RespondInternalError(w, "Failed to delete area")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Area deleted successfully",
+	})
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupAreaTestRouter はArea用のテストルーターをセットアップします
+func setupAreaTestRouter(authMiddleware func(http.Handler) http.Handler, areaHandler *AreaHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/areas", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Post("/", areaHandler.CreateArea)
+		r.Get("/", areaHandler.GetAreas)
+		r.Get("/containing", areaHandler.GetContainingAreas)
+		r.Get("/{id}", areaHandler.GetArea)
+		r.Delete("/{id}", areaHandler.DeleteArea)
+	})
+
+	return r
+}
+
+// TestAreaHandler はAreaエンドポイントのテスト
+func TestAreaHandler(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	connectRepo := repository.NewConnectRepository(testDB.DB)
+	areaRepo := repository.NewAreaRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	router := setupAreaTestRouter(middleware.NewAuthMiddleware(keySet, store), NewAreaHandler(service.NewAreaService(areaRepo, pinRepo, connectRepo)))
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// send はリクエストを送信します
+	send := func(method, url, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, url, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// createSquare は約1km四方の正方形の頂点になるPinを作成します
+	createSquare := func(userID string) []*model.Pin {
+		coords := [][2]float64{
+			{35.6800, 139.7600},
+			{35.6800, 139.7710},
+			{35.6890, 139.7710},
+			{35.6890, 139.7600},
+		}
+		pins := make([]*model.Pin, 0, len(coords))
+		for i, c := range coords {
+			pin, err := helper.CreateTestPin(userID, "トイレ"+string(rune('A'+i)), c[0], c[1])
+			require.NoError(t, err)
+			pins = append(pins, pin)
+		}
+		return pins
+	}
+
+	t.Run("成功: Pinの環からAreaを作成", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pins := createSquare(user.ID)
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := send(http.MethodPost, "/api/areas", token, model.CreateAreaRequest{
+			Name:   "皇居周辺",
+			PinIDs: []string{pins[0].ID, pins[1].ID, pins[2].ID, pins[3].ID},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var area model.Area
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &area))
+		assert.Equal(t, "皇居周辺", area.Name)
+		assert.Equal(t, []string{pins[0].ID, pins[1].ID, pins[2].ID, pins[3].ID}, area.PinIDs)
+		assert.Contains(t, string(area.Geometry), `"Polygon"`)
+		// 約0.99km x 1.0km
+		assert.InDelta(t, 1_000_000, area.AreaSquareMeters, 100_000)
+		assert.InDelta(t, 35.6845, area.Centroid.Latitude, 0.001)
+		assert.InDelta(t, 139.7655, area.Centroid.Longitude, 0.001)
+
+		// 内側の地点・Pinを含むAreaとして取得できる
+		w = send(http.MethodGet, "/api/areas/containing?lat=35.6845&lng=139.7655", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var areas []*model.Area
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &areas))
+		require.Len(t, areas, 1)
+		assert.Equal(t, area.ID, areas[0].ID)
+
+		inside, err := helper.CreateTestPin(user.ID, "内側", 35.6850, 139.7650)
+		require.NoError(t, err)
+		w = send(http.MethodGet, "/api/areas/containing?pin_id="+inside.ID, token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &areas))
+		assert.Len(t, areas, 1)
+
+		w = send(http.MethodGet, "/api/areas/containing?lat=35.7000&lng=139.7655", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &areas))
+		assert.Empty(t, areas)
+
+		// 一覧・取得・削除
+		w = send(http.MethodGet, "/api/areas", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.AreaPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Items, 1)
+
+		w = send(http.MethodGet, "/api/areas/"+area.ID, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodDelete, "/api/areas/"+area.ID, token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodGet, "/api/areas/"+area.ID, token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("成功: Connectの閉路からAreaを作成", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pins := createSquare(user.ID)
+		token := loginToken(t, authService, user.Email, "password123")
+
+		// 順不同・向きもばらばらのConnect
+		var connectIDs []string
+		for _, pair := range [][2]int{{2, 3}, {1, 0}, {0, 3}, {1, 2}} {
+			connect, err := helper.CreateTestConnect(user.ID, pins[pair[0]].ID, pins[pair[1]].ID, true)
+			require.NoError(t, err)
+			connectIDs = append(connectIDs, connect.ID)
+		}
+
+		w := send(http.MethodPost, "/api/areas", token, model.CreateAreaRequest{
+			Name:       "閉路",
+			ConnectIDs: connectIDs,
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var area model.Area
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &area))
+		assert.Equal(t, []string{pins[2].ID, pins[3].ID, pins[0].ID, pins[1].ID}, area.PinIDs)
+		assert.InDelta(t, 1_000_000, area.AreaSquareMeters, 100_000)
+
+		// 閉路になっていない場合はエラー
+		w = send(http.MethodPost, "/api/areas", token, model.CreateAreaRequest{
+			Name:       "開路",
+			ConnectIDs: connectIDs[:3],
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 無効なリクエスト", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pins := createSquare(user.ID)
+		token := loginToken(t, authService, user.Email, "password123")
+
+		tests := []struct {
+			name string
+			req  model.CreateAreaRequest
+		}{
+			{"名前なし", model.CreateAreaRequest{PinIDs: []string{pins[0].ID, pins[1].ID, pins[2].ID}}},
+			{"PinとConnectの両方", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID, pins[2].ID}, ConnectIDs: []string{pins[0].ID}}},
+			{"Pinが2つ", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID}}},
+			{"Pinの重複", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID, pins[0].ID}}},
+			{"大文字で表記したPinの重複", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID, strings.ToUpper(pins[0].ID)}}},
+			{"UUIDでないPin", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID, "not-a-uuid"}}},
+			// 対角線で交差する蝶ネクタイ型
+			{"自己交差", model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[2].ID, pins[1].ID, pins[3].ID}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := send(http.MethodPost, "/api/areas", token, tt.req)
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			})
+		}
+	})
+
+	t.Run("エラー: 他のユーザーのPinとArea", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pins := createSquare(owner.ID)
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		req := model.CreateAreaRequest{Name: "A", PinIDs: []string{pins[0].ID, pins[1].ID, pins[2].ID}}
+
+		// 非公開のPinは存在しないものとして扱う
+		w := send(http.MethodPost, "/api/areas", otherToken, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = send(http.MethodPost, "/api/areas", ownerToken, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var area model.Area
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &area))
+
+		w = send(http.MethodGet, "/api/areas/"+area.ID, otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodDelete, "/api/areas/"+area.ID, otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodGet, "/api/areas/containing?lat=35.6830&lng=139.7680", otherToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var areas []*model.Area
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &areas))
+		assert.Empty(t, areas)
+	})
+}
@@ -12,18 +12,21 @@ import (
 
 // AuthHandler は認証関連のHTTPハンドラーを提供します
 type AuthHandler struct {
-	authService service.AuthService
+	authService         service.AuthService
+	verificationService service.EmailVerificationService
 }
 
 // NewAuthHandler は新しいAuthHandlerインスタンスを作成します
-func NewAuthHandler(authService service.AuthService) *AuthHandler {
+func NewAuthHandler(authService service.AuthService, verificationService service.EmailVerificationService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:         authService,
+		verificationService: verificationService,
 	}
 }
 
 // SignUp はユーザー登録を処理します
 // POST /api/auth/signup
+// 登録したメールアドレスに確認用のトークンを送信します。確認が完了するまでPinを公開できません
 // 要件: 1.1, 1.4, 1.5
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	// リクエストボディのパース
@@ -58,6 +61,9 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 確認メールの送信は登録のレスポンスを待たせない
+	h.verificationService.SendVerificationAsync(user)
+
 	// 成功レスポンス（要件: 1.5）
 	util.RespondJSON(w, http.StatusCreated, user)
 }
@@ -84,7 +90,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// ログイン処理
-	token, user, err := h.authService.Login(r.Context(), req.Email, req.Password)
+	response, err := h.authService.Login(r.Context(), req.Email, req.Password)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredentials) {
 			// 要件: 2.4 - 無効な認証情報の場合
@@ -96,27 +102,159 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 成功レスポンス（要件: 2.3）
-	response := model.AuthResponse{
-		Token: token,
-		User:  user,
+	util.RespondJSON(w, http.StatusOK, response)
+}
+
+// Refresh はリフレッシュトークンを交換し、アクセストークンを再発行します
+// POST /api/auth/refresh
+// 使用したリフレッシュトークンは無効になるため、レスポンスのrefresh_tokenを次回に使用します
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディのパース
+	var req model.RefreshTokenRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateRequired(req.RefreshToken, "refresh_token"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	response, err := h.authService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			util.RespondUnauthorized(w, "Invalid or expired refresh token")
+			return
+		}
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			util.RespondUnauthorized(w, "Refresh token has already been used; please log in again")
+			return
+		}
+		util.RespondInternalError(w, "Failed to refresh token")
+		return
 	}
+
+	// 成功レスポンス
 	util.RespondJSON(w, http.StatusOK, response)
 }
 
+// VerifyEmail はメールアドレスを確認します
+// POST /api/auth/verify-email
+// トークンは確認が完了すると使用できなくなります
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディのパース
+	var req model.VerifyEmailRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateRequired(req.Token, "token"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	user, err := h.verificationService.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidVerificationToken) {
+			util.RespondValidationError(w, "Invalid, expired or already used verification token")
+			return
+		}
+		util.RespondInternalError(w, "Failed to verify email")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, user)
+}
+
+// ResendVerification はメールアドレス確認用のトークンを再送信します
+// POST /api/auth/resend-verification
+// 未登録や確認済みのメールアドレス、送信の失敗でも同じレスポンスを返します
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディのパース
+	var req model.ResendVerificationRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateEmail(req.Email); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	h.verificationService.ResendVerification(req.Email)
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If the address is registered and not yet verified, a verification mail has been sent",
+	})
+}
+
 // Logout はユーザーログアウトを処理します
 // POST /api/auth/logout
+// 使用したアクセストークンは失効し、以降のリクエストでは使用できません
+// ボディにrefresh_tokenを指定すると、同じログインのリフレッシュトークンも失効します
 // 要件: 3.1, 3.2
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// JWTベースの認証では、クライアント側でトークンを削除するだけで十分
-	// サーバー側では特に処理は不要（ステートレス）
-	// 将来的にトークンのブラックリスト機能を追加する場合はここで実装
-	
+	// コンテキストからトークンのクレームを取得（認証ミドルウェアで設定される）
+	claims, ok := middleware.GetTokenClaimsFromContext(r.Context())
+	if !ok || claims == nil {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// リクエストボディは任意
+	var req model.LogoutRequest
+	if r.ContentLength > 0 {
+		if err := util.ParseJSONBody(r, &req); err != nil {
+			util.RespondValidationError(w, "Invalid request body")
+			return
+		}
+	}
+
+	if err := h.authService.Logout(r.Context(), claims, req.RefreshToken); err != nil {
+		if errors.Is(err, service.ErrMissingTokenID) {
+			util.RespondUnauthorized(w, "Unauthorized")
+			return
+		}
+		util.RespondInternalError(w, "Failed to logout")
+		return
+	}
+
 	// 要件: 3.2 - 成功ステータスを返す
 	util.RespondJSON(w, http.StatusOK, map[string]string{
 		"message": "Logged out successfully",
 	})
 }
 
+// LogoutAll はユーザーの全端末からログアウトします
+// POST /api/auth/logout-all
+// 発行済みの全てのアクセストークンとリフレッシュトークンが失効します
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	if err := h.authService.LogoutAll(r.Context(), userID); err != nil {
+		util.RespondInternalError(w, "Failed to logout from all devices")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Logged out from all devices successfully",
+	})
+}
+
 // GetMe は現在のユーザー情報を取得します
 // GET /api/auth/me
 // 要件: 4.1, 4.2, 4.3
@@ -4,46 +4,59 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/mail"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-// TestMain はテストの前に環境変数を設定します
-func TestMain(m *testing.M) {
-	// JWT_SECRETの設定（テスト用）
-	os.Setenv("JWT_SECRET", "test-secret-key-for-jwt-token-generation-32chars")
-	
-	// テストの実行
-	code := m.Run()
-	
-	// 終了
-	os.Exit(code)
+// testJWTSecret はテストでHS256の署名に使用する共有鍵
+const testJWTSecret = "test-secret-key-for-jwt-token-generation-32chars"
+
+// newTestKeySet はテスト用のHS256の鍵の集合を作成します
+func newTestKeySet(t *testing.T) *util.KeySet {
+	t.Helper()
+
+	keySet, err := util.NewHMACKeySet(testJWTSecret)
+	require.NoError(t, err)
+	return keySet
 }
 
 // setupTestRouter はテスト用のルーターをセットアップします
-func setupTestRouter(authHandler *AuthHandler) *chi.Mux {
+func setupTestRouter(authMiddleware func(http.Handler) http.Handler, authHandler *AuthHandler) *chi.Mux {
 	r := chi.NewRouter()
 	
 	r.Route("/api/auth", func(r chi.Router) {
 		r.Post("/signup", authHandler.SignUp)
 		r.Post("/login", authHandler.Login)
+		r.Post("/refresh", authHandler.Refresh)
+		r.Post("/verify-email", authHandler.VerifyEmail)
+		r.Post("/resend-verification", authHandler.ResendVerification)
 		r.Get("/test", authHandler.TestConnection)
 		
 		// 認証が必要なエンドポイント
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware)
+			r.Use(authMiddleware)
 			r.Post("/logout", authHandler.Logout)
+			r.Post("/logout-all", authHandler.LogoutAll)
 			r.Get("/me", authHandler.GetMe)
 		})
 	})
@@ -51,6 +64,68 @@ func setupTestRouter(authHandler *AuthHandler) *chi.Mux {
 	return r
 }
 
+// loginToken はログインしてアクセストークンを返します
+func loginToken(t *testing.T, authService service.AuthService, email, password string) string {
+	t.Helper()
+
+	auth, err := authService.Login(context.Background(), email, password)
+	require.NoError(t, err)
+	return auth.Token
+}
+
+// testVerifyURL はテストで確認メールに記載するリンクのURL
+const testVerifyURL = "http://localhost:3000/verify-email"
+
+// newTestBackgroundTasks はテストの終了時に完了を待つBackgroundTasksを作成します
+func newTestBackgroundTasks(t *testing.T) *service.BackgroundTasks {
+	t.Helper()
+
+	tasks := service.NewBackgroundTasks()
+	t.Cleanup(tasks.Wait)
+	return tasks
+}
+
+// failingMailer は常に送信に失敗するMailer
+type failingMailer struct{}
+
+// Send は送信せずにエラーを返します
+func (failingMailer) Send(ctx context.Context, msg *mail.Message) error {
+	return errors.New("mail server unavailable")
+}
+
+// newTestVerificationService は確認メールをmailDirに保存するEmailVerificationServiceを作成します
+func newTestVerificationService(t *testing.T, userRepo repository.UserRepository, mailDir string, tasks *service.BackgroundTasks) service.EmailVerificationService {
+	t.Helper()
+
+	mailer, err := mail.NewFileMailer(mailDir, "no-reply@example.com")
+	require.NoError(t, err)
+	return service.NewEmailVerificationService(userRepo, mailer, testVerifyURL, newTestKeySet(t), tasks)
+}
+
+// sentMailTokens はmailDirに保存されたtoへのメールから、linkに付けられたトークンを送信順に返します
+func sentMailTokens(t *testing.T, mailDir, to, link string) []string {
+	t.Helper()
+
+	pattern := regexp.MustCompile(regexp.QuoteMeta(link) + `\?token=(\S+)`)
+	files, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.NoError(t, err)
+
+	var tokens []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		if !bytes.Contains(data, []byte("To: "+to+"\r\n")) {
+			continue
+		}
+		match := pattern.FindSubmatch(data)
+		require.NotNil(t, match)
+		token, err := url.QueryUnescape(string(match[1]))
+		require.NoError(t, err)
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
 // TestAuthHandler_SignUp はユーザー登録エンドポイントのテスト
 // 要件: 1.1, 1.4, 1.5
 func TestAuthHandler_SignUp(t *testing.T) {
@@ -61,9 +136,11 @@ func TestAuthHandler_SignUp(t *testing.T) {
 
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	authHandler := NewAuthHandler(authService)
-	router := setupTestRouter(authHandler)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
 
 	t.Run("成功: 有効なリクエストでユーザー登録", func(t *testing.T) {
 		defer testDB.CleanupData()
@@ -185,9 +262,11 @@ func TestAuthHandler_Login(t *testing.T) {
 
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	authHandler := NewAuthHandler(authService)
-	router := setupTestRouter(authHandler)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -223,6 +302,8 @@ func TestAuthHandler_Login(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &authResp)
 		require.NoError(t, err)
 		assert.NotEmpty(t, authResp.Token)
+		assert.NotEmpty(t, authResp.RefreshToken)
+		assert.Equal(t, 15*60, authResp.ExpiresIn)
 		assert.NotNil(t, authResp.User)
 		assert.Equal(t, "terakai@gmail.com", authResp.User.Email)
 		assert.Equal(t, "tera", authResp.User.Name)
@@ -283,31 +364,50 @@ func TestAuthHandler_Logout(t *testing.T) {
 	testDB, err := database.SetupTestDB()
 	require.NoError(t, err)
 	defer testDB.Teardown()
-	defer testDB.CleanupData()
+
+	// 失効ストアは認証ミドルウェアと共有する
+	store := revocation.NewPostgresStore(testDB.DB)
 
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	authHandler := NewAuthHandler(authService)
-	router := setupTestRouter(authHandler)
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
 
+	// send はトークンを付けてリクエストを送信します
+	send := func(method, url, token string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// refresh はリフレッシュトークンを送信します
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(model.RefreshTokenRequest{RefreshToken: refreshToken})
+		return send(http.MethodPost, "/api/auth/refresh", "", body)
+	}
+
 	t.Run("成功: 認証済みユーザーのログアウト", func(t *testing.T) {
+		defer testDB.CleanupData()
+
 		// テストユーザーの作成
 		user, err := helper.CreateTestUser("terakai@gmail.com", "12345678", "tera")
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "12345678")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "12345678")
+		other := loginToken(t, authService, user.Email, "12345678")
 
-		req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
-		req.Header.Set("Authorization", "Bearer "+token)
-		w := httptest.NewRecorder()
-
-		router.ServeHTTP(w, req)
+		w := send(http.MethodPost, "/api/auth/logout", token, nil)
 
 		// 要件: 3.2 - 成功ステータスを返す
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -316,16 +416,97 @@ func TestAuthHandler_Logout(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &resp)
 		require.NoError(t, err)
 		assert.Contains(t, resp["message"], "Logged out successfully")
+
+		// ログアウトしたトークンは使用できない
+		w = send(http.MethodGet, "/api/auth/me", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// 別のログインのトークンは影響を受けない
+		w = send(http.MethodGet, "/api/auth/me", other, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("エラー: 未認証のログアウト試行", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
-		w := httptest.NewRecorder()
+	t.Run("成功: リフレッシュトークンも失効させる", func(t *testing.T) {
+		defer testDB.CleanupData()
 
-		router.ServeHTTP(w, req)
+		user, err := helper.CreateTestUser("terakai@gmail.com", "12345678", "tera")
+		require.NoError(t, err)
+		login, err := authService.Login(context.Background(), user.Email, "12345678")
+		require.NoError(t, err)
+
+		body, _ := json.Marshal(model.LogoutRequest{RefreshToken: login.RefreshToken})
+		w := send(http.MethodPost, "/api/auth/logout", login.Token, body)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = refresh(login.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("成功: 全端末からのログアウト", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("terakai@gmail.com", "12345678", "tera")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "12345678", "other")
+		require.NoError(t, err)
+		first, err := authService.Login(context.Background(), user.Email, "12345678")
+		require.NoError(t, err)
+		second, err := authService.Login(context.Background(), user.Email, "12345678")
+		require.NoError(t, err)
+		otherToken := loginToken(t, authService, other.Email, "12345678")
+
+		w := send(http.MethodPost, "/api/auth/logout-all", first.Token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// 全てのアクセストークンとリフレッシュトークンが失効する
+		w = send(http.MethodGet, "/api/auth/me", first.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = send(http.MethodGet, "/api/auth/me", second.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = refresh(second.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// 他のユーザーは影響を受けない
+		w = send(http.MethodGet, "/api/auth/me", otherToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// 再ログインすれば使用できる
+		token := loginToken(t, authService, user.Email, "12345678")
+		w = send(http.MethodGet, "/api/auth/me", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("エラー: jtiのないトークンは受け付けない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("terakai@gmail.com", "12345678", "tera")
+		require.NoError(t, err)
+
+		// jtiがないと個別に失効できない
+		claims := util.JWTClaims{
+			UserID: user.ID,
+			Email:  user.Email,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(util.AccessTokenTTL)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+		require.NoError(t, err)
+
+		w := send(http.MethodGet, "/api/auth/me", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = send(http.MethodPost, "/api/auth/logout", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("エラー: 未認証のログアウト試行", func(t *testing.T) {
+		w := send(http.MethodPost, "/api/auth/logout", "", nil)
 
 		// 要件: 4.3 - 未認証の場合
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = send(http.MethodPost, "/api/auth/logout-all", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
@@ -340,9 +521,11 @@ func TestAuthHandler_GetMe(t *testing.T) {
 
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	authHandler := NewAuthHandler(authService)
-	router := setupTestRouter(authHandler)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -353,8 +536,7 @@ func TestAuthHandler_GetMe(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "12345678")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "12345678")
 
 		req := httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -404,9 +586,11 @@ func TestAuthHandler_TestConnection(t *testing.T) {
 
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	authHandler := NewAuthHandler(authService)
-	router := setupTestRouter(authHandler)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
 
 	t.Run("成功: データベース接続テスト", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/auth/test", nil)
@@ -424,3 +608,245 @@ func TestAuthHandler_TestConnection(t *testing.T) {
 		assert.Contains(t, resp["message"], "Database connection successful")
 	})
 }
+
+// TestAuthHandler_Refresh はトークン再発行エンドポイントのテスト
+func TestAuthHandler_Refresh(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t)))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// refresh はリフレッシュトークンを送信します
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(model.RefreshTokenRequest{RefreshToken: refreshToken})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: トークンを交換して再発行", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		login, err := authService.Login(context.Background(), user.Email, "password123")
+		require.NoError(t, err)
+
+		w := refresh(login.RefreshToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var authResp model.AuthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &authResp))
+		assert.NotEmpty(t, authResp.Token)
+		assert.NotEmpty(t, authResp.RefreshToken)
+		assert.NotEqual(t, login.RefreshToken, authResp.RefreshToken)
+		assert.Equal(t, user.ID, authResp.User.ID)
+
+		// 新しいトークンでさらに交換できる
+		w = refresh(authResp.RefreshToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// トークンはハッシュのみ保存される
+		var count int
+		require.NoError(t, testDB.DB.Get(&count, "SELECT COUNT(*) FROM refresh_tokens WHERE token_hash = $1", login.RefreshToken))
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("エラー: 交換済みのトークンを再使用すると系列全体が失効", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		login, err := authService.Login(context.Background(), user.Email, "password123")
+		require.NoError(t, err)
+		other, err := authService.Login(context.Background(), user.Email, "password123")
+		require.NoError(t, err)
+
+		w := refresh(login.RefreshToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		var rotated model.AuthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+
+		// 交換済みのトークンを再使用
+		w = refresh(login.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// 同じ系列の最新のトークンも使用できなくなる
+		w = refresh(rotated.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// 別のログインの系列は影響を受けない
+		w = refresh(other.RefreshToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("エラー: 無効・期限切れのトークン", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		w := refresh("")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = refresh("unknown-token")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		login, err := authService.Login(context.Background(), user.Email, "password123")
+		require.NoError(t, err)
+		_, err = testDB.DB.Exec("UPDATE refresh_tokens SET expires_at = NOW() - INTERVAL '1 second'")
+		require.NoError(t, err)
+
+		w = refresh(login.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// TestAuthHandler_VerifyEmail はメールアドレス確認エンドポイントのテスト
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	mailDir := t.TempDir()
+	tasks := newTestBackgroundTasks(t)
+	authHandler := NewAuthHandler(authService, newTestVerificationService(t, userRepo, mailDir, tasks))
+	router := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), authHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// post はJSONのリクエストを送信します
+	post := func(url string, reqBody interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: 登録時に送信されたトークンで確認", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		w := post("/api/auth/signup", model.SignUpRequest{Email: "new@example.com", Password: "password123", Name: "New User"})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var user model.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Nil(t, user.EmailVerifiedAt)
+
+		// 確認メールはレスポンスとは別に送信される
+		tasks.Wait()
+		tokens := sentMailTokens(t, mailDir, "new@example.com", testVerifyURL)
+		require.Len(t, tokens, 1)
+
+		w = post("/api/auth/verify-email", model.VerifyEmailRequest{Token: tokens[0]})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.NotNil(t, user.EmailVerifiedAt)
+
+		// トークンは1回のみ使用できる
+		w = post("/api/auth/verify-email", model.VerifyEmailRequest{Token: tokens[0]})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("成功: 未確認のユーザーにのみ再送信", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		_, err := helper.CreateUnverifiedTestUser("unverified@example.com", "password123", "Unverified")
+		require.NoError(t, err)
+		_, err = helper.CreateTestUser("verified@example.com", "password123", "Verified")
+		require.NoError(t, err)
+
+		// 登録の有無や確認状態に関わらず同じレスポンスを返す
+		for _, email := range []string{"unverified@example.com", "verified@example.com", "unknown@example.com"} {
+			w := post("/api/auth/resend-verification", model.ResendVerificationRequest{Email: email})
+			assert.Equal(t, http.StatusAccepted, w.Code)
+		}
+
+		tasks.Wait()
+		assert.Empty(t, sentMailTokens(t, mailDir, "verified@example.com", testVerifyURL))
+		assert.Empty(t, sentMailTokens(t, mailDir, "unknown@example.com", testVerifyURL))
+		tokens := sentMailTokens(t, mailDir, "unverified@example.com", testVerifyURL)
+		require.Len(t, tokens, 1)
+
+		w := post("/api/auth/verify-email", model.VerifyEmailRequest{Token: tokens[0]})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("エラー: 確認メールの送信に失敗すると記録され、再送信で解除される", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		failing := service.NewEmailVerificationService(userRepo, failingMailer{}, testVerifyURL, keySet, tasks)
+		failRouter := setupTestRouter(middleware.NewAuthMiddleware(keySet, store), NewAuthHandler(authService, failing))
+
+		body, _ := json.Marshal(model.SignUpRequest{Email: "new@example.com", Password: "password123", Name: "New User"})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/signup", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		failRouter.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var user model.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+
+		tasks.Wait()
+		stored, err := helper.GetUserByID(user.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.VerificationMailFailedAt)
+
+		// 再送信の失敗もレスポンスからは分からない
+		body, _ = json.Marshal(model.ResendVerificationRequest{Email: user.Email})
+		req = httptest.NewRequest(http.MethodPost, "/api/auth/resend-verification", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		failRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		tasks.Wait()
+
+		w = post("/api/auth/resend-verification", model.ResendVerificationRequest{Email: user.Email})
+		require.Equal(t, http.StatusAccepted, w.Code)
+		tasks.Wait()
+		stored, err = helper.GetUserByID(user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.VerificationMailFailedAt)
+		assert.Len(t, sentMailTokens(t, mailDir, user.Email, testVerifyURL), 1)
+	})
+
+	t.Run("エラー: 無効なトークン", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		w := post("/api/auth/verify-email", model.VerifyEmailRequest{Token: ""})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/verify-email", model.VerifyEmailRequest{Token: "invalid-token"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// アクセストークンはメールアドレスの確認に使用できない
+		user, err := helper.CreateUnverifiedTestUser("unverified@example.com", "password123", "Unverified")
+		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
+		w = post("/api/auth/verify-email", model.VerifyEmailRequest{Token: token})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post("/api/auth/resend-verification", model.ResendVerificationRequest{Email: "invalid"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
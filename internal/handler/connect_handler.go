@@ -62,6 +62,9 @@ func (h *ConnectHandler) CreateConnect(w http.ResponseWriter, r *http.Request) {
 			util.RespondValidationError(w, "Invalid pin IDs")
 			return
 		}
+		if respondConnectIntegrityError(w, err) {
+			return
+		}
 		util.RespondInternalError(w, "Failed to create connect")
 		return
 	}
@@ -116,6 +119,9 @@ func (h *ConnectHandler) UpdateConnect(w http.ResponseWriter, r *http.Request) {
 			util.RespondNotFound(w, "One or both pins do not exist")
 			return
 		}
+		if respondConnectIntegrityError(w, err) {
+			return
+		}
 		util.RespondInternalError(w, "Failed to update connect")
 		return
 	}
@@ -124,8 +130,11 @@ func (h *ConnectHandler) UpdateConnect(w http.ResponseWriter, r *http.Request) {
 	util.RespondJSON(w, http.StatusOK, connect)
 }
 
-// GetConnects はユーザーのConnect一覧を取得します
-// GET /api/connects
+// GetConnects はユーザーのConnect一覧を新しい順に取得します
+// GET /api/connects?limit=&cursor=&expand=pins
+// レスポンスのnext_cursorをcursorに指定すると次のページを取得できます
+// expand=pinsを指定すると両端のPinをpin_1、pin_2として含めます
+// ?format=geojson または Accept: application/geo+json の場合は全件をGeoJSON形式で返します
 // 要件: 8.1, 9.1
 func (h *ConnectHandler) GetConnects(w http.ResponseWriter, r *http.Request) {
 	// コンテキストからユーザーIDを取得
@@ -135,15 +144,90 @@ func (h *ConnectHandler) GetConnects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// ユーザーのConnect一覧を取得
-	connects, err := h.connectService.GetConnectsByUser(r.Context(), userID)
+	// GeoJSON形式が要求された場合はLineStringのFeatureCollectionを返す
+	if util.WantsGeoJSON(r) {
+		lines, err := h.connectService.GetConnectsByUser(r.Context(), userID)
+		if err != nil {
+			util.RespondInternalError(w, "Failed to get connects")
+			return
+		}
+		util.RespondGeoJSON(w, http.StatusOK, connectLinesToFeatureCollection(lines))
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	expandPins, err := parseExpandPins(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// ユーザーのConnect一覧を1ページ分取得
+	page, err := h.connectService.GetConnectPageByUser(r.Context(), userID, r.URL.Query().Get("cursor"), limit)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
 		util.RespondInternalError(w, "Failed to get connects")
 		return
 	}
 
+	if expandPins {
+		if err := h.connectService.ExpandConnectPins(r.Context(), userID, page.Items); err != nil {
+			util.RespondInternalError(w, "Failed to get connects")
+			return
+		}
+	}
+
 	// 成功レスポンス
-	util.RespondJSON(w, http.StatusOK, connects)
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// GetPath は表示中のConnectを辿ったPin間の最短経路を取得します
+// GET /api/connects/path?from={pinID}&to={pinID}
+func (h *ConnectHandler) GetPath(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := r.Context().Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// バリデーション
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if err := util.ValidateRequired(from, "from"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	if err := util.ValidateRequired(to, "to"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// 経路探索
+	path, err := h.connectService.FindPath(r.Context(), userID, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotExist) {
+			util.RespondNotFound(w, "One or both pins do not exist")
+			return
+		}
+		if errors.Is(err, service.ErrNoPath) {
+			util.RespondError(w, http.StatusNotFound, util.ErrCodeNoPath, "No path of shown connects between these pins")
+			return
+		}
+		util.RespondInternalError(w, "Failed to find path")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, path)
 }
 
 // DeleteConnect はConnectを削除します
@@ -184,3 +268,31 @@ func (h *ConnectHandler) DeleteConnect(w http.ResponseWriter, r *http.Request) {
 		"message": "Connect deleted successfully",
 	})
 }
+
+// parseExpandPins はクエリパラメータexpandを検証し、Pinを含めるかを返します
+func parseExpandPins(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("expand") {
+	case "":
+		return false, nil
+	case "pins":
+		return true, nil
+	default:
+		return false, errors.New("expand must be pins")
+	}
+}
+
+// respondConnectIntegrityError はConnectの整合性エラーをそれぞれのエラーコードで返します
+// 整合性エラーでない場合はfalseを返します
+func respondConnectIntegrityError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrSelfConnect):
+		util.RespondError(w, http.StatusBadRequest, util.ErrCodeSelfConnect, "Cannot connect a pin to itself")
+	case errors.Is(err, service.ErrDuplicateConnect):
+		util.RespondError(w, http.StatusConflict, util.ErrCodeDuplicateConnect, "A connect between these pins already exists")
+	case errors.Is(err, service.ErrPinNotOwned):
+		util.RespondError(w, http.StatusForbidden, util.ErrCodePinNotOwned, "You can only connect your own pins")
+	default:
+		return false
+	}
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -13,21 +14,23 @@ import (
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // setupConnectTestRouter はConnect用のテストルーターをセットアップします
-func setupConnectTestRouter(connectHandler *ConnectHandler) *chi.Mux {
+func setupConnectTestRouter(authMiddleware func(http.Handler) http.Handler, connectHandler *ConnectHandler) *chi.Mux {
 	r := chi.NewRouter()
 	
 	r.Route("/api/connects", func(r chi.Router) {
 		// 認証が必要なエンドポイント
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware)
+			r.Use(authMiddleware)
 			r.Post("/", connectHandler.CreateConnect)
 			r.Get("/", connectHandler.GetConnects)
+			r.Get("/path", connectHandler.GetPath)
 			r.Put("/{id}", connectHandler.UpdateConnect)
 			r.Delete("/{id}", connectHandler.DeleteConnect)
 		})
@@ -48,11 +51,13 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
 	connectRepo := repository.NewConnectRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	// pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	// pinService := service.NewPinService(pinRepo, userRepo)
 	connectService := service.NewConnectService(connectRepo, pinRepo)
 	connectHandler := NewConnectHandler(connectService)
-	router := setupConnectTestRouter(connectHandler)
+	router := setupConnectTestRouter(middleware.NewAuthMiddleware(keySet, store), connectHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -71,8 +76,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: pin1.ID,
@@ -115,8 +119,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: pin1.ID,
@@ -167,8 +170,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: "nonexistent-pin-1",
@@ -205,8 +207,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: pin1.ID,
@@ -234,8 +235,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: "",
@@ -262,8 +262,7 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreateConnectRequest{
 			PinID1: "pin1-id",
@@ -281,6 +280,72 @@ func TestConnectHandler_CreateConnect(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("エラー: 自己ループ・重複・他のユーザーのPin", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+
+		// テストPinの作成
+		pin1, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(user.ID, "トイレB", 35.7000, 139.7000)
+		require.NoError(t, err)
+		publicPin, err := helper.CreateTestPin(other.ID, "公開トイレ", 35.7100, 139.7100)
+		require.NoError(t, err)
+		_, err = testDB.DB.Exec("UPDATE pins SET visibility = 'public' WHERE id = $1", publicPin.ID)
+		require.NoError(t, err)
+		privatePin, err := helper.CreateTestPin(other.ID, "非公開トイレ", 35.7200, 139.7200)
+		require.NoError(t, err)
+
+		// 既存のConnectの作成
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		tests := []struct {
+			name   string
+			pinID1 string
+			pinID2 string
+			status int
+			code   string
+		}{
+			{"同じPin同士", pin1.ID, pin1.ID, http.StatusBadRequest, model.ErrCodeSelfConnect},
+			{"表記の異なる同じPin同士", pin1.ID, strings.ToUpper(pin1.ID), http.StatusBadRequest, model.ErrCodeSelfConnect},
+			{"既存のConnectと同じ組み合わせ", pin1.ID, pin2.ID, http.StatusConflict, model.ErrCodeDuplicateConnect},
+			{"既存のConnectと逆順の組み合わせ", pin2.ID, pin1.ID, http.StatusConflict, model.ErrCodeDuplicateConnect},
+			{"他のユーザーの公開Pin", pin1.ID, publicPin.ID, http.StatusForbidden, model.ErrCodePinNotOwned},
+			{"他のユーザーの非公開Pin", pin1.ID, privatePin.ID, http.StatusNotFound, model.ErrCodeNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				body, _ := json.Marshal(model.CreateConnectRequest{
+					PinID1: tt.pinID1,
+					PinID2: tt.pinID2,
+					Show:   true,
+				})
+
+				req := httptest.NewRequest(http.MethodPost, "/api/connects/", bytes.NewBuffer(body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", "Bearer "+token)
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.status, w.Code)
+				var errResp model.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, tt.code, errResp.Error.Code)
+			})
+		}
+	})
 }
 
 // TestConnectHandler_UpdateConnect はConnect更新エンドポイントのテスト
@@ -295,11 +360,13 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
 	connectRepo := repository.NewConnectRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	// pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	// pinService := service.NewPinService(pinRepo, userRepo)
 	connectService := service.NewConnectService(connectRepo, pinRepo)
 	connectHandler := NewConnectHandler(connectService)
-	router := setupConnectTestRouter(connectHandler)
+	router := setupConnectTestRouter(middleware.NewAuthMiddleware(keySet, store), connectHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -324,8 +391,7 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		showFalse := false
 		reqBody := model.UpdateConnectRequest{
@@ -372,8 +438,7 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		showFalse := false
 		reqBody := model.UpdateConnectRequest{
@@ -418,8 +483,7 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// ユーザー2のトークンを生成
-		token, _, err := authService.Login(context.Background(), user2.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user2.Email, "password123")
 
 		showFalse := false
 		reqBody := model.UpdateConnectRequest{
@@ -451,8 +515,7 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		showFalse := false
 		reqBody := model.UpdateConnectRequest{
@@ -488,8 +551,7 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.UpdateConnectRequest{
 			PinID1: pin1.ID,
@@ -507,6 +569,51 @@ func TestConnectHandler_UpdateConnect(t *testing.T) {
 		// 要件: 9.5 - Pin存在確認
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("エラー: 更新で自己ループ・重複になる", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinの作成
+		pin1, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(user.ID, "トイレB", 35.7000, 139.7000)
+		require.NoError(t, err)
+		pin3, err := helper.CreateTestPin(user.ID, "トイレC", 35.7100, 139.7100)
+		require.NoError(t, err)
+
+		// テストConnectの作成
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+		connect, err := helper.CreateTestConnect(user.ID, pin2.ID, pin3.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		update := func(pinID1, pinID2 string) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(model.UpdateConnectRequest{PinID1: pinID1, PinID2: pinID2})
+			req := httptest.NewRequest(http.MethodPut, "/api/connects/"+connect.ID, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := update(pin3.ID, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = update(pin1.ID, pin2.ID)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// Pinを入れ替えるだけの更新は重複にならない
+		w = update(pin3.ID, pin2.ID)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 }
 
 // TestConnectHandler_GetConnects はConnect一覧取得エンドポイントのテスト
@@ -521,11 +628,13 @@ func TestConnectHandler_GetConnects(t *testing.T) {
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
 	connectRepo := repository.NewConnectRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	// pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	// pinService := service.NewPinService(pinRepo, userRepo)
 	connectService := service.NewConnectService(connectRepo, pinRepo)
 	connectHandler := NewConnectHandler(connectService)
-	router := setupConnectTestRouter(connectHandler)
+	router := setupConnectTestRouter(middleware.NewAuthMiddleware(keySet, store), connectHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -552,8 +661,7 @@ func TestConnectHandler_GetConnects(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodGet, "/api/connects/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -563,10 +671,12 @@ func TestConnectHandler_GetConnects(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var connects []model.Connect
-		err = json.Unmarshal(w.Body.Bytes(), &connects)
+		var page model.ConnectPage
+		err = json.Unmarshal(w.Body.Bytes(), &page)
 		require.NoError(t, err)
+		connects := page.Items
 		assert.Len(t, connects, 2)
+		assert.Empty(t, page.NextCursor)
 
 		// Connect IDの確認
 		connectIDs := []string{connects[0].ID, connects[1].ID}
@@ -574,17 +684,106 @@ func TestConnectHandler_GetConnects(t *testing.T) {
 		assert.Contains(t, connectIDs, connect2.ID)
 	})
 
-	t.Run("成功: Connectが0件の場合", func(t *testing.T) {
+	t.Run("成功: 両端の座標・距離・方位とexpand=pins", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 東京駅から新宿駅へのConnect（約6.1km、ほぼ西向き）
+		pin1, err := helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(user.ID, "新宿駅", 35.6896, 139.7006)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/connects/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page model.ConnectPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		line := page.Items[0]
+		assert.InDelta(t, 35.6812, line.From.Latitude, 1e-9)
+		assert.InDelta(t, 139.7006, line.To.Longitude, 1e-9)
+		assert.InDelta(t, 6085, line.DistanceMeters, 50)
+		assert.InDelta(t, 278.8, line.Bearing, 1)
+		require.NotNil(t, line.Geometry)
+		assert.Equal(t, model.GeoJSONTypeLineString, line.Geometry.Type)
+		assert.Nil(t, line.Pin1)
+
+		// expand=pinsで両端のPinを含める
+		req = httptest.NewRequest(http.MethodGet, "/api/connects/?expand=pins", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		require.NotNil(t, page.Items[0].Pin1)
+		require.NotNil(t, page.Items[0].Pin2)
+		assert.Equal(t, "東京駅", page.Items[0].Pin1.Name)
+		assert.Equal(t, "新宿駅", page.Items[0].Pin2.Name)
+
+		// 未対応のexpand
+		req = httptest.NewRequest(http.MethodGet, "/api/connects/?expand=users", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("成功: 他のユーザーの非公開Pinを参照するConnectは含まれない", func(t *testing.T) {
 		defer testDB.CleanupData()
 
 		// テストユーザーの作成
 		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
 		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+
+		// 他のユーザーのPinへの接続が制限される前に作成されたConnect
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		privatePin, err := helper.CreateTestPin(other.ID, "非公開トイレ", 35.7000, 139.7000)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin.ID, privatePin.ID, true)
+		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
+		token := loginToken(t, authService, user.Email, "password123")
+
+		for _, url := range []string{"/api/connects/", "/api/connects/?format=geojson"} {
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.NotContains(t, w.Body.String(), privatePin.ID)
+			assert.NotContains(t, w.Body.String(), "139.7,")
+		}
+	})
+
+	t.Run("成功: Connectが0件の場合", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
 		require.NoError(t, err)
 
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
 		req := httptest.NewRequest(http.MethodGet, "/api/connects/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
@@ -593,10 +792,80 @@ func TestConnectHandler_GetConnects(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var connects []model.Connect
-		err = json.Unmarshal(w.Body.Bytes(), &connects)
+		var page model.ConnectPage
+		err = json.Unmarshal(w.Body.Bytes(), &page)
 		require.NoError(t, err)
-		assert.Len(t, connects, 0)
+		assert.NotNil(t, page.Items)
+		assert.Len(t, page.Items, 0)
+	})
+
+	t.Run("成功: limitとcursorでページ分割して取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinとConnectの作成
+		pin1, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(user.ID, "トイレB", 35.7000, 139.7000)
+		require.NoError(t, err)
+		pin3, err := helper.CreateTestPin(user.ID, "トイレC", 35.7100, 139.7100)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin2.ID, pin3.ID, true)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin3.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		// 1ページ目
+		req := httptest.NewRequest(http.MethodGet, "/api/connects/?limit=2", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var first model.ConnectPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+		assert.Len(t, first.Items, 2)
+		require.NotEmpty(t, first.NextCursor)
+
+		// 2ページ目
+		req = httptest.NewRequest(http.MethodGet, "/api/connects/?limit=2&cursor="+first.NextCursor, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var second model.ConnectPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.Len(t, second.Items, 1)
+		assert.Empty(t, second.NextCursor)
+		assert.NotContains(t, []string{first.Items[0].ID, first.Items[1].ID}, second.Items[0].ID)
+	})
+
+	t.Run("エラー: 不正なカーソル", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/connects/?cursor=invalid!", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("エラー: 未認証のリクエスト", func(t *testing.T) {
@@ -623,11 +892,13 @@ func TestConnectHandler_DeleteConnect(t *testing.T) {
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
 	connectRepo := repository.NewConnectRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	// pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	// pinService := service.NewPinService(pinRepo, userRepo)
 	connectService := service.NewConnectService(connectRepo, pinRepo)
 	connectHandler := NewConnectHandler(connectService)
-	router := setupConnectTestRouter(connectHandler)
+	router := setupConnectTestRouter(middleware.NewAuthMiddleware(keySet, store), connectHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -650,8 +921,7 @@ func TestConnectHandler_DeleteConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/connects/"+connect.ID, nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -695,8 +965,7 @@ func TestConnectHandler_DeleteConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// ユーザー2のトークンを生成
-		token, _, err := authService.Login(context.Background(), user2.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user2.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/connects/"+connect.ID, nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -721,8 +990,7 @@ func TestConnectHandler_DeleteConnect(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/connects/nonexistent-id", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -744,3 +1012,126 @@ func TestConnectHandler_DeleteConnect(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+// TestConnectHandler_GetPath はConnectの経路探索エンドポイントのテスト
+func TestConnectHandler_GetPath(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	connectRepo := repository.NewConnectRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	connectService := service.NewConnectService(connectRepo, pinRepo)
+	connectHandler := NewConnectHandler(connectService)
+	router := setupConnectTestRouter(middleware.NewAuthMiddleware(keySet, store), connectHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// getPath は経路探索のリクエストを送信します
+	getPath := func(token, from, to string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/connects/path?from="+from+"&to="+to, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: 距離が最短の経路を取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// A-B-Dは東へ直進、A-C-Dは北へ大きく迂回する
+		pinA, err := helper.CreateTestPin(user.ID, "トイレA", 35.6800, 139.7600)
+		require.NoError(t, err)
+		pinB, err := helper.CreateTestPin(user.ID, "トイレB", 35.6800, 139.7700)
+		require.NoError(t, err)
+		pinC, err := helper.CreateTestPin(user.ID, "トイレC", 35.7000, 139.7700)
+		require.NoError(t, err)
+		pinD, err := helper.CreateTestPin(user.ID, "トイレD", 35.6800, 139.7800)
+		require.NoError(t, err)
+
+		ab, err := helper.CreateTestConnect(user.ID, pinA.ID, pinB.ID, true)
+		require.NoError(t, err)
+		bd, err := helper.CreateTestConnect(user.ID, pinD.ID, pinB.ID, true)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pinA.ID, pinC.ID, true)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pinC.ID, pinD.ID, true)
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := getPath(token, pinA.ID, pinD.ID)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var path model.ConnectPath
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &path))
+		require.Len(t, path.Pins, 3)
+		assert.Equal(t, pinA.ID, path.Pins[0].ID)
+		assert.Equal(t, pinB.ID, path.Pins[1].ID)
+		assert.Equal(t, pinD.ID, path.Pins[2].ID)
+		assert.Equal(t, []string{ab.ID, bd.ID}, path.ConnectIDs)
+		// 経度0.02度（約1.8km）
+		assert.InDelta(t, 1810, path.DistanceMeters, 20)
+	})
+
+	t.Run("エラー: show=falseのConnectは辿らない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pinA, err := helper.CreateTestPin(user.ID, "トイレA", 35.6800, 139.7600)
+		require.NoError(t, err)
+		pinB, err := helper.CreateTestPin(user.ID, "トイレB", 35.6800, 139.7700)
+		require.NoError(t, err)
+		pinC, err := helper.CreateTestPin(user.ID, "トイレC", 35.6800, 139.7800)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pinA.ID, pinB.ID, true)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pinB.ID, pinC.ID, false)
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := getPath(token, pinA.ID, pinC.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var errResp model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+		assert.Equal(t, model.ErrCodeNoPath, errResp.Error.Code)
+	})
+
+	t.Run("エラー: 無効なパラメータ", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6800, 139.7600)
+		require.NoError(t, err)
+		otherPin, err := helper.CreateTestPin(other.ID, "トイレB", 35.6800, 139.7700)
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := getPath(token, pin.ID, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// 非公開の他のユーザーのPinは存在しないものとして扱う
+		w = getPath(token, pin.ID, otherPin.ID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var errResp model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+		assert.Equal(t, model.ErrCodeNotFound, errResp.Error.Code)
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/geofile"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// exportFilePrefix はエクスポートファイル名の接頭辞
+const exportFilePrefix = "unchingspot-pins"
+
+// ExportHandler はPinとConnectのエクスポート用HTTPハンドラーを提供します
+type ExportHandler struct {
+	pinService     service.PinService
+	connectService service.ConnectService
+}
+
+// NewExportHandler は新しいExportHandlerインスタンスを作成します
+func NewExportHandler(pinService service.PinService, connectService service.ConnectService) *ExportHandler {
+	return &ExportHandler{
+		pinService:     pinService,
+		connectService: connectService,
+	}
+}
+
+// ExportPins は認証されたユーザーのPinとConnectをファイルとしてダウンロードさせます
+// GET /api/pins/export?format=gpx|kml|csv|geojson
+// GPXではConnectをルート、KMLではLineStringとして出力します（CSVはPinのみ）
+// 全件をメモリに保持せず、データベースから読み取った行を順次書き出します
+func (h *ExportHandler) ExportPins(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得（認証ミドルウェアで設定される）
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	format, err := geofile.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		util.RespondValidationError(w, "format must be one of gpx, kml, csv, geojson")
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s%s", exportFilePrefix, time.Now().UTC().Format("20060102"), geofile.Extension(format))
+	w.Header().Set("Content-Type", geofile.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// 書き出し開始後はステータスコードを変更できないため、以降のエラーはログに記録する
+	writer, err := geofile.NewWriter(format, w)
+	if err != nil {
+		log.Printf("failed to start export: %v", err)
+		return
+	}
+
+	err = h.pinService.EachPinByUser(r.Context(), userID, writer.WritePin)
+	if err != nil {
+		log.Printf("failed to export pins: %v", err)
+		return
+	}
+
+	err = h.connectService.EachConnectLineByUser(r.Context(), userID, writer.WriteConnect)
+	if err != nil {
+		log.Printf("failed to export connects: %v", err)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("failed to finish export: %v", err)
+	}
+}
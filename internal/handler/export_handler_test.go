@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupExportTestRouter はエクスポート用のテストルーターをセットアップします
+func setupExportTestRouter(authMiddleware func(http.Handler) http.Handler, exportHandler *ExportHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/pins", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/export", exportHandler.ExportPins)
+	})
+
+	return r
+}
+
+// TestExportHandler_ExportPins はPinエクスポートエンドポイントのテスト
+func TestExportHandler_ExportPins(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	connectRepo := repository.NewConnectRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	connectService := service.NewConnectService(connectRepo, pinRepo)
+	exportHandler := NewExportHandler(pinService, connectService)
+	router := setupExportTestRouter(middleware.NewAuthMiddleware(keySet, store), exportHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// setup はPin2件とConnect1件を持つユーザーを作成し、トークンを返します
+	setup := func(t *testing.T) string {
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pin1, err := helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(user.ID, "新宿", 35.6896, 139.7006)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(user.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+
+		// 他のユーザーのPinはエクスポートされない
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(other.ID, "他人のPin", 35.0, 135.0)
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+		return token
+	}
+
+	t.Run("成功: GPX形式でウェイポイントとルートを出力", func(t *testing.T) {
+		defer testDB.CleanupData()
+		token := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=gpx", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/gpx+xml", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=\"unchingspot-pins-")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), ".gpx\"")
+
+		body := w.Body.String()
+		assert.Equal(t, 2, strings.Count(body, "<wpt "))
+		assert.Equal(t, 1, strings.Count(body, "<rte>"))
+		assert.NotContains(t, body, "他人のPin")
+	})
+
+	t.Run("成功: KML形式でLineStringを出力", func(t *testing.T) {
+		defer testDB.CleanupData()
+		token := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=kml", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, strings.Count(w.Body.String(), "<Point>"))
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "<LineString>"))
+	})
+
+	t.Run("成功: CSV形式はPinのみ出力", func(t *testing.T) {
+		defer testDB.CleanupData()
+		token := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=csv", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Len(t, lines, 3)
+	})
+
+	t.Run("成功: GeoJSON形式でPointとLineStringを出力", func(t *testing.T) {
+		defer testDB.CleanupData()
+		token := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=geojson", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var fc model.FeatureCollection
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fc))
+		assert.Len(t, fc.Features, 3)
+	})
+
+	t.Run("失敗: 未対応の形式", func(t *testing.T) {
+		defer testDB.CleanupData()
+		token := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=shp", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("失敗: 認証なし", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/export?format=gpx", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,29 @@
+package handler
+
+import "github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+
+// pinsToFeatureCollection はPin一覧をPoint FeatureのFeatureCollectionに変換します
+func pinsToFeatureCollection(pins []*model.Pin) *model.FeatureCollection {
+	features := make([]*model.Feature, 0, len(pins))
+	for _, pin := range pins {
+		features = append(features, model.NewPinFeature(pin))
+	}
+
+	return &model.FeatureCollection{
+		Type:     model.GeoJSONTypeFeatureCollection,
+		Features: features,
+	}
+}
+
+// connectLinesToFeatureCollection はConnect一覧をLineString FeatureのFeatureCollectionに変換します
+func connectLinesToFeatureCollection(lines []*model.ConnectLine) *model.FeatureCollection {
+	features := make([]*model.Feature, 0, len(lines))
+	for _, line := range lines {
+		features = append(features, model.NewConnectLineFeature(line))
+	}
+
+	return &model.FeatureCollection{
+		Type:     model.GeoJSONTypeFeatureCollection,
+		Features: features,
+	}
+}
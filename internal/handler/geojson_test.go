@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPinsToFeatureCollection はPin一覧のGeoJSON変換のテスト
+func TestPinsToFeatureCollection(t *testing.T) {
+	pins := []*model.Pin{
+		{ID: "pin-1", Name: "トイレA", UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917},
+	}
+
+	body, err := json.Marshal(pinsToFeatureCollection(pins))
+	require.NoError(t, err)
+
+	var fc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fc))
+	assert.Equal(t, "FeatureCollection", fc["type"])
+
+	features := fc["features"].([]interface{})
+	require.Len(t, features, 1)
+	feature := features[0].(map[string]interface{})
+	assert.Equal(t, "Feature", feature["type"])
+	assert.Equal(t, "pin-1", feature["id"])
+
+	// 座標は[経度, 緯度]の順序
+	geometry := feature["geometry"].(map[string]interface{})
+	assert.Equal(t, "Point", geometry["type"])
+	assert.Equal(t, []interface{}{139.6917, 35.6895}, geometry["coordinates"])
+
+	properties := feature["properties"].(map[string]interface{})
+	assert.Equal(t, "トイレA", properties["name"])
+	assert.Equal(t, "user-1", properties["user_id"])
+}
+
+// TestPinsToFeatureCollection_Empty は空のPin一覧のGeoJSON変換のテスト
+func TestPinsToFeatureCollection_Empty(t *testing.T) {
+	body, err := json.Marshal(pinsToFeatureCollection(nil))
+	require.NoError(t, err)
+
+	// featuresはnullではなく空配列
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, string(body))
+}
+
+// TestConnectLinesToFeatureCollection はConnect一覧のGeoJSON変換のテスト
+func TestConnectLinesToFeatureCollection(t *testing.T) {
+	lines := []*model.ConnectLine{
+		{
+			Connect: &model.Connect{ID: "connect-1", UserID: "user-1", PinID1: "pin-1", PinID2: "pin-2", Show: true},
+			From:    model.LatLng{Latitude: 35.6812, Longitude: 139.7671},
+			To:      model.LatLng{Latitude: 35.6895, Longitude: 139.6917},
+		},
+	}
+
+	body, err := json.Marshal(connectLinesToFeatureCollection(lines))
+	require.NoError(t, err)
+
+	var fc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fc))
+
+	features := fc["features"].([]interface{})
+	require.Len(t, features, 1)
+	feature := features[0].(map[string]interface{})
+	assert.Equal(t, "connect-1", feature["id"])
+
+	geometry := feature["geometry"].(map[string]interface{})
+	assert.Equal(t, "LineString", geometry["type"])
+	assert.Equal(t, []interface{}{
+		[]interface{}{139.7671, 35.6812},
+		[]interface{}{139.6917, 35.6895},
+	}, geometry["coordinates"])
+
+	properties := feature["properties"].(map[string]interface{})
+	assert.Equal(t, "pin-1", properties["pin_id_1"])
+	assert.Equal(t, "pin-2", properties["pin_id_2"])
+	assert.Equal(t, true, properties["show"])
+}
+
+// TestWantsGeoJSON はGeoJSON形式の判定のテスト
+func TestWantsGeoJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/pins/?format=geojson", nil)
+	assert.True(t, util.WantsGeoJSON(req))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/pins/", nil)
+	req.Header.Set("Accept", "application/geo+json")
+	assert.True(t, util.WantsGeoJSON(req))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/pins/", nil)
+	req.Header.Set("Accept", "application/json")
+	assert.False(t, util.WantsGeoJSON(req))
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// GraphHandler はPinとConnectのネットワーク分析用HTTPハンドラーを提供します
+type GraphHandler struct {
+	connectService service.ConnectService
+}
+
+// NewGraphHandler は新しいGraphHandlerインスタンスを作成します
+func NewGraphHandler(connectService service.ConnectService) *GraphHandler {
+	return &GraphHandler{
+		connectService: connectService,
+	}
+}
+
+// GetConnectGraph はユーザーのPinとConnectのネットワークを取得します
+// GET /api/connects/graph
+// 連結成分、Pinごとの次数、孤立したPin、閉路を含めて返します
+// show=falseのConnectも辺として含めます
+func (h *GraphHandler) GetConnectGraph(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	connectGraph, err := h.connectService.GetConnectGraph(r.Context(), userID)
+	if err != nil {
+		util.RespondInternalError(w, "Failed to get connect graph")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, connectGraph)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGraphTestRouter はネットワーク分析用のテストルーターをセットアップします
+func setupGraphTestRouter(authMiddleware func(http.Handler) http.Handler, graphHandler *GraphHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/connects", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/graph", graphHandler.GetConnectGraph)
+	})
+
+	return r
+}
+
+// TestGraphHandler_GetConnectGraph はConnectのネットワーク取得エンドポイントのテスト
+func TestGraphHandler_GetConnectGraph(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	connectRepo := repository.NewConnectRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	connectService := service.NewConnectService(connectRepo, pinRepo)
+	router := setupGraphTestRouter(middleware.NewAuthMiddleware(keySet, store), NewGraphHandler(connectService))
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// getGraph はネットワークを取得します
+	getGraph := func(t *testing.T, token string) model.ConnectGraph {
+		req := httptest.NewRequest(http.MethodGet, "/api/connects/graph", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var g model.ConnectGraph
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+		return g
+	}
+
+	t.Run("成功: 連結成分・次数・孤立したPin・閉路", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 三角形A-B-Cと、線分D-E、孤立したF
+		pinA, err := helper.CreateTestPin(user.ID, "トイレA", 35.6800, 139.7600)
+		require.NoError(t, err)
+		pinB, err := helper.CreateTestPin(user.ID, "トイレB", 35.6800, 139.7700)
+		require.NoError(t, err)
+		pinC, err := helper.CreateTestPin(user.ID, "トイレC", 35.6900, 139.7650)
+		require.NoError(t, err)
+		pinD, err := helper.CreateTestPin(user.ID, "トイレD", 35.7000, 139.7000)
+		require.NoError(t, err)
+		pinE, err := helper.CreateTestPin(user.ID, "トイレE", 35.7100, 139.7000)
+		require.NoError(t, err)
+		pinF, err := helper.CreateTestPin(user.ID, "トイレF", 35.7200, 139.7000)
+		require.NoError(t, err)
+
+		for _, pair := range [][2]*model.Pin{{pinA, pinB}, {pinB, pinC}, {pinC, pinA}, {pinD, pinE}} {
+			_, err := helper.CreateTestConnect(user.ID, pair[0].ID, pair[1].ID, true)
+			require.NoError(t, err)
+		}
+
+		// 他のユーザーのPinとConnectは含まれない
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(other.ID, "他人のPin", 35.0, 135.0)
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		g := getGraph(t, token)
+		assert.Len(t, g.Nodes, 6)
+		assert.Len(t, g.Edges, 4)
+		assert.Len(t, g.Components, 3)
+		assert.Equal(t, []string{pinF.ID}, g.IsolatedPins)
+
+		degrees := make(map[string]int)
+		components := make(map[string]int)
+		for _, node := range g.Nodes {
+			degrees[node.ID] = node.Degree
+			components[node.ID] = node.Component
+		}
+		assert.Equal(t, 2, degrees[pinA.ID])
+		assert.Equal(t, 1, degrees[pinD.ID])
+		assert.Equal(t, 0, degrees[pinF.ID])
+		assert.Equal(t, components[pinA.ID], components[pinC.ID])
+		assert.NotEqual(t, components[pinA.ID], components[pinD.ID])
+		assert.ElementsMatch(t, []string{pinA.ID, pinB.ID, pinC.ID}, g.Components[components[pinA.ID]])
+
+		require.Len(t, g.Cycles, 1)
+		assert.ElementsMatch(t, []string{pinA.ID, pinB.ID, pinC.ID}, g.Cycles[0].PinIDs)
+		assert.Len(t, g.Cycles[0].ConnectIDs, 3)
+	})
+
+	t.Run("成功: PinもConnectもない場合", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
+
+		g := getGraph(t, token)
+		assert.NotNil(t, g.Nodes)
+		assert.Empty(t, g.Nodes)
+		assert.Empty(t, g.Edges)
+		assert.Empty(t, g.Components)
+		assert.Empty(t, g.IsolatedPins)
+		assert.Empty(t, g.Cycles)
+	})
+}
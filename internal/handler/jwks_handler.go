@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// JWKSHandler はアクセストークンの検証に使用する公開鍵を公開するHTTPハンドラーを提供します
+type JWKSHandler struct {
+	keySet *util.KeySet
+}
+
+// NewJWKSHandler は新しいJWKSHandlerインスタンスを作成します
+// JWT_SECRETによるHS256の場合、共有鍵は公開しないため空の鍵の集合を返します
+func NewJWKSHandler(keySet *util.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keySet: keySet,
+	}
+}
+
+// GetJWKS はアクセストークンの検証に使用する公開鍵をJWKS形式で返します
+// GET /.well-known/jwks.json
+// 他のサービスはトークンのkidに対応する鍵で署名を検証できます
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	jwks := h.keySet.JWKS()
+
+	w.Header().Set("Cache-Control", util.JWKSCacheControl)
+	util.RespondJSON(w, http.StatusOK, jwks)
+}
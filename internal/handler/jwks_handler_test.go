@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJWKSHandler_GetJWKS は公開鍵エンドポイントのテスト
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	// get はJWKSを取得します
+	get := func(h *JWKSHandler) (*httptest.ResponseRecorder, util.JWKS) {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		w := httptest.NewRecorder()
+		h.GetJWKS(w, req)
+
+		var jwks util.JWKS
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+		return w, jwks
+	}
+
+	t.Run("成功: 検証に使用する公開鍵を返す", func(t *testing.T) {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		ks, err := util.NewKeySet([]*util.SigningKey{
+			{ID: "current", Algorithm: util.AlgEdDSA, Private: private, Public: public},
+			{ID: "previous", Algorithm: util.AlgEdDSA, Public: public},
+		}, "current")
+		require.NoError(t, err)
+
+		w, jwks := get(NewJWKSHandler(ks))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, util.JWKSCacheControl, w.Header().Get("Cache-Control"))
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "current", jwks.Keys[0].KeyID)
+		assert.Equal(t, "previous", jwks.Keys[1].KeyID)
+
+		// 秘密鍵は含まれない
+		assert.NotContains(t, w.Body.String(), `"d"`)
+	})
+
+	t.Run("成功: HS256の共有鍵は公開しない", func(t *testing.T) {
+		ks, err := util.NewHMACKeySet("test-secret-key-for-jwt-token-generation-32chars")
+		require.NoError(t, err)
+
+		w, jwks := get(NewJWKSHandler(ks))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotNil(t, jwks.Keys)
+		assert.Empty(t, jwks.Keys)
+		assert.Contains(t, w.Body.String(), `"keys":[]`)
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// PasswordResetHandler はパスワード再設定関連のHTTPハンドラーを提供します
+type PasswordResetHandler struct {
+	passwordResetService service.PasswordResetService
+}
+
+// NewPasswordResetHandler は新しいPasswordResetHandlerインスタンスを作成します
+func NewPasswordResetHandler(passwordResetService service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+	}
+}
+
+// ForgotPassword はパスワード再設定用のトークンをメールで送信します
+// POST /api/auth/forgot-password
+// 未登録のメールアドレスや送信の失敗でも同じレスポンスを返します
+func (h *PasswordResetHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディのパース
+	var req model.ForgotPasswordRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateEmail(req.Email); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	h.passwordResetService.RequestPasswordReset(req.Email)
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If the address is registered, a password reset mail has been sent",
+	})
+}
+
+// ResetPassword はパスワード再設定用のトークンで新しいパスワードを設定します
+// POST /api/auth/reset-password
+// 再設定すると全ての端末からログアウトされるため、新しいパスワードで再ログインが必要です
+func (h *PasswordResetHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディのパース
+	var req model.ResetPasswordRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateRequired(req.Token, "token"); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	if err := util.ValidatePassword(req.Password); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			util.RespondValidationError(w, "Invalid, expired or already used password reset token")
+			return
+		}
+		util.RespondInternalError(w, "Failed to reset password")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Password reset successfully",
+	})
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/mail"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResetURL はテストで再設定メールに記載するリンクのURL
+const testResetURL = "http://localhost:3000/reset-password"
+
+// setupPasswordResetTestRouter はパスワード再設定用のテストルーターをセットアップします
+func setupPasswordResetTestRouter(authMiddleware func(http.Handler) http.Handler, authHandler *AuthHandler, passwordResetHandler *PasswordResetHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/auth", func(r chi.Router) {
+		r.Post("/login", authHandler.Login)
+		r.Post("/refresh", authHandler.Refresh)
+		r.Post("/forgot-password", passwordResetHandler.ForgotPassword)
+		r.Post("/reset-password", passwordResetHandler.ResetPassword)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Get("/me", authHandler.GetMe)
+		})
+	})
+
+	return r
+}
+
+// TestPasswordResetHandler はパスワード再設定エンドポイントのテスト
+func TestPasswordResetHandler(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// 失効ストアは認証ミドルウェアと共有する
+	// メモリ上のStoreでも既存のアクセストークンが失効することを確認する
+	store := revocation.NewMemoryStore()
+
+	// メールはテストごとの一時ディレクトリに保存
+	mailDir := t.TempDir()
+	mailer, err := mail.NewFileMailer(mailDir, "no-reply@example.com")
+	require.NoError(t, err)
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	keySet := newTestKeySet(t)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(testDB.DB)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, store, keySet)
+	tasks := newTestBackgroundTasks(t)
+	passwordResetService := service.NewPasswordResetService(userRepo, repository.NewPasswordResetTokenRepository(testDB.DB), refreshTokenRepo, store, mailer, testResetURL, tasks)
+	router := setupPasswordResetTestRouter(
+		middleware.NewAuthMiddleware(keySet, store),
+		NewAuthHandler(authService, newTestVerificationService(t, userRepo, t.TempDir(), newTestBackgroundTasks(t))),
+		NewPasswordResetHandler(passwordResetService),
+	)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// send はJSONのリクエストを送信します
+	send := func(method, url, token string, reqBody interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if reqBody != nil {
+			body, _ = json.Marshal(reqBody)
+		}
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// login はログインしてステータスコードを返します
+	login := func(email, password string) int {
+		return send(http.MethodPost, "/api/auth/login", "", model.LoginRequest{Email: email, Password: password}).Code
+	}
+
+	t.Run("成功: 再設定すると新しいパスワードでログインでき、既存のセッションは失効する", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		session, err := authService.Login(context.Background(), user.Email, "password123")
+		require.NoError(t, err)
+
+		w := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: user.Email})
+		require.Equal(t, http.StatusAccepted, w.Code)
+		tasks.Wait()
+		tokens := sentMailTokens(t, mailDir, user.Email, testResetURL)
+		require.Len(t, tokens, 1)
+
+		// トークンはハッシュのみ保存される
+		var count int
+		require.NoError(t, testDB.DB.Get(&count, "SELECT COUNT(*) FROM password_reset_tokens WHERE token_hash = $1", tokens[0]))
+		assert.Equal(t, 0, count)
+
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[0], Password: "newpassword456"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		assert.Equal(t, http.StatusUnauthorized, login(user.Email, "password123"))
+		assert.Equal(t, http.StatusOK, login(user.Email, "newpassword456"))
+
+		// 既存のアクセストークンとリフレッシュトークンは使用できない
+		w = send(http.MethodGet, "/api/auth/me", session.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = send(http.MethodPost, "/api/auth/refresh", "", model.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		// トークンは1回のみ使用できる
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[0], Password: "anotherpassword789"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("成功: 未登録のメールアドレスでも同じレスポンス", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		_, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		registered := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: "test@example.com"})
+		unknown := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: "unknown@example.com"})
+		assert.Equal(t, http.StatusAccepted, registered.Code)
+		assert.Equal(t, registered.Code, unknown.Code)
+		assert.Equal(t, registered.Body.String(), unknown.Body.String())
+
+		tasks.Wait()
+		assert.Empty(t, sentMailTokens(t, mailDir, "unknown@example.com", testResetURL))
+	})
+
+	t.Run("成功: 再設定すると他の未使用のトークンも使用できなくなる", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("other@example.com", "password123", "Other User")
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			w := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: user.Email})
+			require.Equal(t, http.StatusAccepted, w.Code)
+			tasks.Wait()
+
+			// 再発行できるよう、発行済みのトークンを再発行の間隔より前に作成したことにする
+			_, err := testDB.DB.Exec("UPDATE password_reset_tokens SET created_at = created_at - INTERVAL '1 hour' WHERE user_id = $1", user.ID)
+			require.NoError(t, err)
+		}
+		tokens := sentMailTokens(t, mailDir, user.Email, testResetURL)
+		require.Len(t, tokens, 2)
+
+		w := send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[1], Password: "newpassword456"})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[0], Password: "anotherpassword789"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusOK, login(user.Email, "newpassword456"))
+	})
+
+	t.Run("成功: 再発行の間隔内の要求ではトークンを発行しない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("cooldown@example.com", "password123", "Cooldown User")
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			w := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: user.Email})
+			require.Equal(t, http.StatusAccepted, w.Code)
+			tasks.Wait()
+		}
+		assert.Len(t, sentMailTokens(t, mailDir, user.Email, testResetURL), 1)
+
+		var count int
+		require.NoError(t, testDB.DB.Get(&count, "SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = $1", user.ID))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("エラー: 無効・期限切れのトークンと不正なパスワード", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		w := send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: "invalid"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: "unknown-token", Password: "newpassword456"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		user, err := helper.CreateTestUser("expired@example.com", "password123", "Expired User")
+		require.NoError(t, err)
+		w = send(http.MethodPost, "/api/auth/forgot-password", "", model.ForgotPasswordRequest{Email: user.Email})
+		require.Equal(t, http.StatusAccepted, w.Code)
+		tasks.Wait()
+		tokens := sentMailTokens(t, mailDir, user.Email, testResetURL)
+		require.Len(t, tokens, 1)
+
+		// 短すぎるパスワードではトークンを消費しない
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[0], Password: "short"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		_, err = testDB.DB.Exec("UPDATE password_reset_tokens SET expires_at = NOW() - INTERVAL '1 second'")
+		require.NoError(t, err)
+		w = send(http.MethodPost, "/api/auth/reset-password", "", model.ResetPasswordRequest{Token: tokens[0], Password: "newpassword456"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusOK, login(user.Email, "password123"))
+	})
+}
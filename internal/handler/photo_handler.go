@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+const (
+	// MaxPhotoFileSize はアップロードできる写真の最大サイズ（10MB）
+	MaxPhotoFileSize = 10 << 20
+	// photoMultipartOverhead はmultipartのヘッダーや境界文字列のための余裕
+	photoMultipartOverhead = 64 << 10
+)
+
+// errPhotoFileTooLarge は写真のファイルサイズが上限を超えたエラー
+var errPhotoFileTooLarge = errors.New("photo file is too large")
+
+// PhotoHandler はPinの写真関連のHTTPハンドラーを提供します
+type PhotoHandler struct {
+	photoService service.PhotoService
+}
+
+// NewPhotoHandler は新しいPhotoHandlerインスタンスを作成します
+func NewPhotoHandler(photoService service.PhotoService) *PhotoHandler {
+	return &PhotoHandler{
+		photoService: photoService,
+	}
+}
+
+// UploadPhoto はPinに写真を追加します
+// POST /api/pins/:id/photos
+// multipart/form-dataのfileフィールドでJPEGまたはPNGの画像を受け取ります
+// 画像の位置情報（EXIFのGPS情報など）は保存前に取り除かれます
+func (h *PhotoHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	// リクエストサイズの制限（multipartのヘッダー分の余裕を含める）
+	r.Body = http.MaxBytesReader(w, r.Body, MaxPhotoFileSize+photoMultipartOverhead)
+
+	data, err := readPhotoFile(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) || errors.Is(err, errPhotoFileTooLarge) {
+			util.RespondError(w, http.StatusRequestEntityTooLarge, util.ErrCodeInvalidInput,
+				fmt.Sprintf("File must be at most %d bytes", MaxPhotoFileSize))
+			return
+		}
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	photo, err := h.photoService.UploadPhoto(r.Context(), pinID, userID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPinNotFound):
+			util.RespondNotFound(w, "Pin not found")
+		case errors.Is(err, service.ErrInvalidPhoto):
+			util.RespondValidationError(w, "File must be a JPEG or PNG image")
+		case errors.Is(err, service.ErrPhotoTooLarge):
+			util.RespondValidationError(w, "Image dimensions are too large")
+		case errors.Is(err, service.ErrTooManyPhotos):
+			util.RespondValidationError(w, fmt.Sprintf("A pin can have at most %d photos", model.MaxPhotosPerPin))
+		default:
+			util.RespondInternalError(w, "Failed to upload photo")
+		}
+		return
+	}
+
+	// 成功レスポンス
+	setPhotoURLs(photo)
+	util.RespondJSON(w, http.StatusCreated, photo)
+}
+
+// GetPhotos はPinの写真一覧を取得します
+// GET /api/pins/:id/photos
+func (h *PhotoHandler) GetPhotos(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	photos, err := h.photoService.GetPhotosByPin(r.Context(), pinID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get photos")
+		return
+	}
+
+	// 成功レスポンス
+	for _, photo := range photos {
+		setPhotoURLs(photo)
+	}
+	util.RespondJSON(w, http.StatusOK, photos)
+}
+
+// GetPhoto は写真の画像を返します
+// GET /api/pins/:id/photos/:photoId?size=original|thumbnail
+func (h *PhotoHandler) GetPhoto(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDと写真IDを取得
+	pinID := chi.URLParam(r, "id")
+	photoID := chi.URLParam(r, "photoId")
+	if pinID == "" || photoID == "" {
+		util.RespondValidationError(w, "Pin ID and photo ID are required")
+		return
+	}
+
+	var thumbnail bool
+	switch r.URL.Query().Get("size") {
+	case "", "original":
+	case "thumbnail":
+		thumbnail = true
+	default:
+		util.RespondValidationError(w, "size must be one of original, thumbnail")
+		return
+	}
+
+	photo, body, err := h.photoService.OpenPhoto(r.Context(), pinID, photoID, userID, thumbnail)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrPhotoNotFound) {
+			util.RespondNotFound(w, "Photo not found")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get photo")
+		return
+	}
+	defer body.Close()
+
+	contentType := photo.ContentType
+	if thumbnail {
+		contentType = "image/jpeg"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// 写真の内容は変更されないため、閲覧権限のあるクライアントにのみキャッシュさせる
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+
+	// 書き出し開始後はステータスコードを変更できないため、以降のエラーはログに記録する
+	if _, err := io.Copy(w, body); err != nil {
+		log.Printf("failed to write photo %s: %v", photoID, err)
+	}
+}
+
+// DeletePhoto は写真を削除します
+// DELETE /api/pins/:id/photos/:photoId
+// 削除できるのは写真を追加したユーザーとPinの所有者のみです
+func (h *PhotoHandler) DeletePhoto(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDと写真IDを取得
+	pinID := chi.URLParam(r, "id")
+	photoID := chi.URLParam(r, "photoId")
+	if pinID == "" || photoID == "" {
+		util.RespondValidationError(w, "Pin ID and photo ID are required")
+		return
+	}
+
+	err := h.photoService.DeletePhoto(r.Context(), pinID, photoID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrPhotoNotFound) {
+			util.RespondNotFound(w, "Photo not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPhotoAccess) {
+			util.RespondForbidden(w, "You don't have permission to delete this photo")
+			return
+		}
+		util.RespondInternalError(w, "Failed to delete photo")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Photo deleted successfully",
+	})
+}
+
+// readPhotoFile はmultipartリクエストのfileパートを読み取ります
+func readPhotoFile(r *http.Request) ([]byte, error) {
+	var buf bytes.Buffer
+	err := util.ReadMultipartFile(r, "file", func(part *multipart.Part) error {
+		n, err := buf.ReadFrom(io.LimitReader(part, MaxPhotoFileSize+1))
+		if err != nil {
+			return err
+		}
+		if n > MaxPhotoFileSize {
+			return errPhotoFileTooLarge
+		}
+		if n == 0 {
+			return errors.New("file is empty")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// setPhotoURLs は写真の画像とサムネイルを取得するURLを設定します
+func setPhotoURLs(photo *model.Photo) {
+	photo.URL = fmt.Sprintf("/api/pins/%s/photos/%s", photo.PinID, photo.ID)
+	photo.ThumbnailURL = photo.URL + "?size=thumbnail"
+}
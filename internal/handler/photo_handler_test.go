@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/jpeg"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPhotoTestRouter は写真用のテストルーターをセットアップします
+func setupPhotoTestRouter(authMiddleware func(http.Handler) http.Handler, pinHandler *PinHandler, photoHandler *PhotoHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/pins", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Delete("/{id}", pinHandler.DeletePin)
+		r.Get("/{id}/photos", photoHandler.GetPhotos)
+		r.Post("/{id}/photos", photoHandler.UploadPhoto)
+		r.Get("/{id}/photos/{photoId}", photoHandler.GetPhoto)
+		r.Delete("/{id}/photos/{photoId}", photoHandler.DeletePhoto)
+	})
+
+	return r
+}
+
+// TestPhotoHandler は写真エンドポイントのテスト
+func TestPhotoHandler(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// ストレージはテストごとの一時ディレクトリを使用
+	photoStorage, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	photoRepo := repository.NewPhotoRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	photoService := service.NewPhotoService(photoRepo, pinRepo, photoStorage)
+	router := setupPhotoTestRouter(middleware.NewAuthMiddleware(keySet, store), NewPinHandler(pinService), NewPhotoHandler(photoService))
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// テスト用のJPEG画像
+	var img bytes.Buffer
+	require.NoError(t, jpeg.Encode(&img, image.NewRGBA(image.Rect(0, 0, 640, 480)), nil))
+
+	// upload はfileフィールドに画像を添付してアップロードします
+	upload := func(url, token string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "photo.jpg")
+		require.NoError(t, err)
+		_, err = part.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, url, &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// send はボディ無しのリクエストを送信します
+	send := func(method, url, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: 写真のアップロード・取得・削除", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, owner.Email, "password123")
+
+		url := "/api/pins/" + pin.ID + "/photos"
+
+		// アップロード
+		w := upload(url, token, img.Bytes())
+		require.Equal(t, http.StatusCreated, w.Code)
+		var photo model.Photo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &photo))
+		assert.Equal(t, "image/jpeg", photo.ContentType)
+		assert.Equal(t, 640, photo.Width)
+		assert.Equal(t, 480, photo.Height)
+		assert.Equal(t, url+"/"+photo.ID, photo.URL)
+
+		// 一覧
+		w = send(http.MethodGet, url, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var photos []*model.Photo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &photos))
+		require.Len(t, photos, 1)
+
+		// 元画像とサムネイルの取得
+		w = send(http.MethodGet, photo.URL, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+
+		w = send(http.MethodGet, photo.ThumbnailURL, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		thumb, err := jpeg.Decode(w.Body)
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 320, 240), thumb.Bounds())
+
+		// 削除
+		w = send(http.MethodDelete, photo.URL, token)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodGet, photo.URL, token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("成功: Pinの削除で写真も削除される", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, owner.Email, "password123")
+
+		w := upload("/api/pins/"+pin.ID+"/photos", token, img.Bytes())
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = send(http.MethodDelete, "/api/pins/"+pin.ID, token)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var remaining int
+		require.NoError(t, testDB.DB.Get(&remaining, "SELECT COUNT(*) FROM photos WHERE pin_id = $1 AND deleted_at IS NULL", pin.ID))
+		assert.Equal(t, 0, remaining)
+	})
+
+	t.Run("エラー: 同時に追加しても写真の枚数は上限を超えない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		newPhoto := func() *model.Photo {
+			return &model.Photo{PinID: pin.ID, UserID: owner.ID, StorageKey: "key", ThumbnailKey: "thumb", ContentType: "image/jpeg", Width: 1, Height: 1, SizeBytes: 1}
+		}
+		for i := 0; i < model.MaxPhotosPerPin-1; i++ {
+			created, err := photoRepo.Create(context.Background(), newPhoto(), model.MaxPhotosPerPin)
+			require.NoError(t, err)
+			require.True(t, created)
+		}
+
+		// 残り1枚の枠に同時に追加
+		var wg sync.WaitGroup
+		results := make([]bool, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				created, err := photoRepo.Create(context.Background(), newPhoto(), model.MaxPhotosPerPin)
+				assert.NoError(t, err)
+				results[i] = created
+			}(i)
+		}
+		wg.Wait()
+
+		createdCount := 0
+		for _, created := range results {
+			if created {
+				createdCount++
+			}
+		}
+		assert.Equal(t, 1, createdCount)
+
+		count, err := photoRepo.CountByPinID(context.Background(), pin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MaxPhotosPerPin, count)
+
+		// 上限に達したPinにはアップロードできない
+		token := loginToken(t, authService, owner.Email, "password123")
+		w := upload("/api/pins/"+pin.ID+"/photos", token, img.Bytes())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 無効な画像と非公開のPin", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		url := "/api/pins/" + pin.ID + "/photos"
+
+		// 画像ではないファイル
+		w := upload(url, ownerToken, []byte("not an image"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// 非公開のPinには他のユーザーは写真を追加・閲覧できない
+		w = upload(url, otherToken, img.Bytes())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = send(http.MethodGet, url, otherToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -2,15 +2,27 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/geofile"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 )
 
+const (
+	// MaxImportFileSize はインポートファイルの最大サイズ（10MB）
+	MaxImportFileSize = 10 << 20
+	// importMultipartOverhead はmultipartのヘッダーや境界文字列のための余裕
+	importMultipartOverhead = 64 << 10
+)
+
 // PinHandler はPin関連のHTTPハンドラーを提供します
 type PinHandler struct {
 	pinService service.PinService
@@ -52,12 +64,16 @@ func (h *PinHandler) CreatePin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Pin作成処理（要件: 6.1）
-	pin, err := h.pinService.CreatePin(r.Context(), userID, req.Name, req.Latitude, req.Longitude)
+	pin, err := h.pinService.CreatePin(r.Context(), userID, req.Name, req.Latitude, req.Longitude, req.PinAttributes)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCoordinates) {
 			util.RespondValidationError(w, "Invalid coordinates")
 			return
 		}
+		if errors.Is(err, service.ErrInvalidPinAttributes) {
+			util.RespondValidationError(w, "Invalid pin attributes")
+			return
+		}
 		util.RespondInternalError(w, "Failed to create pin")
 		return
 	}
@@ -66,6 +82,52 @@ func (h *PinHandler) CreatePin(w http.ResponseWriter, r *http.Request) {
 	util.RespondJSON(w, http.StatusCreated, pin)
 }
 
+// ImportPins はGPX/KML/GeoJSONファイルからPinを一括作成します
+// POST /api/pins/import?format=gpx|kml|geojson
+// multipart/form-dataのfileフィールドでファイルを受け取り、行ごとの処理結果を返します
+// formatを省略した場合はファイル名の拡張子から形式を判定します
+func (h *PinHandler) ImportPins(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// リクエストサイズの制限（multipartのヘッダー分の余裕を含める）
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportFileSize+importMultipartOverhead)
+
+	// fileパートを逐次読み取り
+	rows, err := readImportFile(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			util.RespondError(w, http.StatusRequestEntityTooLarge, util.ErrCodeInvalidInput,
+				fmt.Sprintf("File must be at most %d bytes", MaxImportFileSize))
+		case errors.Is(err, service.ErrTooManyImportRows):
+			util.RespondValidationError(w, fmt.Sprintf("File must contain at most %d points", service.MaxImportRows))
+		default:
+			util.RespondValidationError(w, err.Error())
+		}
+		return
+	}
+
+	// インポート処理
+	report, err := h.pinService.ImportPins(r.Context(), userID, rows)
+	if err != nil {
+		if errors.Is(err, service.ErrTooManyImportRows) {
+			util.RespondValidationError(w, fmt.Sprintf("File must contain at most %d points", service.MaxImportRows))
+			return
+		}
+		util.RespondInternalError(w, "Failed to import pins")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, report)
+}
+
 // UpdatePin はPinを更新します
 // PUT /api/pins/:id
 // 要件: 7.1, 7.5
@@ -102,7 +164,7 @@ func (h *PinHandler) UpdatePin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Pin更新処理（要件: 7.1）
-	pin, err := h.pinService.UpdatePin(r.Context(), pinID, userID, req.Name, req.Latitude, req.Longitude)
+	pin, err := h.pinService.UpdatePin(r.Context(), pinID, userID, req.Name, req.Latitude, req.Longitude, req.PinAttributesUpdate)
 	if err != nil {
 		if errors.Is(err, service.ErrPinNotFound) {
 			util.RespondNotFound(w, "Pin not found")
@@ -117,6 +179,10 @@ func (h *PinHandler) UpdatePin(w http.ResponseWriter, r *http.Request) {
 			util.RespondValidationError(w, "Invalid coordinates")
 			return
 		}
+		if errors.Is(err, service.ErrInvalidPinAttributes) {
+			util.RespondValidationError(w, "Invalid pin attributes")
+			return
+		}
 		util.RespondInternalError(w, "Failed to update pin")
 		return
 	}
@@ -125,8 +191,154 @@ func (h *PinHandler) UpdatePin(w http.ResponseWriter, r *http.Request) {
 	util.RespondJSON(w, http.StatusOK, pin)
 }
 
-// GetPins はユーザーのPin一覧を取得します
-// GET /api/pins
+// GetPinHistory はPinの編集履歴を新しい順に取得します
+// GET /api/pins/:id/history?limit=&cursor=
+// 取得できるのはPinの所有者のみです
+func (h *PinHandler) GetPinHistory(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	page, err := h.pinService.GetPinHistory(r.Context(), pinID, userID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPinAccess) {
+			util.RespondForbidden(w, "You don't have permission to view the history of this pin")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get pin history")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// RevertPin はPinの名前と位置を指定されたリビジョンの更新前の状態に戻します
+// POST /api/pins/:id/revert/:revision
+// 取り消し自体も新しいリビジョンとして編集履歴に記録されます
+func (h *PinHandler) RevertPin(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDとリビジョン番号を取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil || revision <= 0 {
+		util.RespondValidationError(w, "revision must be a positive integer")
+		return
+	}
+
+	pin, err := h.pinService.RevertPin(r.Context(), pinID, userID, revision)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrPinRevisionNotFound) {
+			util.RespondNotFound(w, "Revision not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPinAccess) {
+			util.RespondForbidden(w, "You don't have permission to update this pin")
+			return
+		}
+		util.RespondInternalError(w, "Failed to revert pin")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, pin)
+}
+
+// UpdatePinVisibility はPinの公開範囲を変更します
+// PUT /api/pins/:id/visibility
+// shared-by-linkに変更するとレスポンスのshare_tokenで共有リンクを作成できます
+func (h *PinHandler) UpdatePinVisibility(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	// リクエストボディのパース
+	var req model.UpdatePinVisibilityRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	pin, err := h.pinService.SetPinVisibility(r.Context(), pinID, userID, req.Visibility)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidVisibility) {
+			util.RespondValidationError(w, "visibility must be one of private, shared-by-link, public")
+			return
+		}
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPinAccess) {
+			util.RespondForbidden(w, "You don't have permission to update this pin")
+			return
+		}
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			util.RespondError(w, http.StatusForbidden, util.ErrCodeEmailNotVerified, "Verify your email address before making pins public")
+			return
+		}
+		util.RespondInternalError(w, "Failed to update pin visibility")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, pin)
+}
+
+// GetPins はユーザーのPin一覧を新しい順に取得します
+// GET /api/pins?limit=&cursor=&wheelchair_accessible=&baby_changing=&gender_neutral=&washlet=&paid=&open_now=&at=
+// レスポンスのnext_cursorをcursorに指定すると次のページを取得できます
+// 設備属性のパラメータを指定すると、その値を持つPinのみに絞り込みます
+// open_now=trueを指定すると、現在（atを指定した場合はその時刻）に営業中のPinのみに絞り込みます
+// ?format=geojson または Accept: application/geo+json の場合は全件をGeoJSON形式で返します
 // 要件: 6.1, 7.1
 func (h *PinHandler) GetPins(w http.ResponseWriter, r *http.Request) {
 	// コンテキストからユーザーIDを取得
@@ -136,21 +348,243 @@ func (h *PinHandler) GetPins(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// ユーザーのPin一覧を取得
-	pins, err := h.pinService.GetPinsByUser(r.Context(), userID)
+	filter, err := parsePinFilter(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// GeoJSON形式が要求された場合はPointのFeatureCollectionを返す
+	if util.WantsGeoJSON(r) {
+		pins, err := h.pinService.GetPinsByUser(r.Context(), userID, filter)
+		if err != nil {
+			util.RespondInternalError(w, "Failed to get pins")
+			return
+		}
+		util.RespondGeoJSON(w, http.StatusOK, pinsToFeatureCollection(pins))
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// ユーザーのPin一覧を1ページ分取得
+	page, err := h.pinService.GetPinPageByUser(r.Context(), userID, filter, r.URL.Query().Get("cursor"), limit)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
 		util.RespondInternalError(w, "Failed to get pins")
 		return
 	}
 
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// SearchNearbyPins は指定地点の近くにあるユーザーのPinを近い順に取得します
+// GET /api/pins/nearby?lat=&lng=&radius_m=&limit=
+// GET /api/pins と同じ設備属性・営業中の絞り込みに対応します
+func (h *PinHandler) SearchNearbyPins(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// クエリパラメータのパース
+	lat, err := util.ParseFloatQuery(r, "lat")
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	lng, err := util.ParseFloatQuery(r, "lng")
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	radius, err := util.ParseFloatQuery(r, "radius_m")
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	limit, err := util.ParseIntQuery(r, "limit", service.DefaultNearbyLimit)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	filter, err := parsePinFilter(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// バリデーション
+	if err := util.ValidateCoordinates(lat, lng); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// 近傍検索
+	pins, err := h.pinService.SearchNearbyPins(r.Context(), userID, lat, lng, radius, filter, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCoordinates) {
+			util.RespondValidationError(w, "Invalid coordinates")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidSearchRadius) {
+			util.RespondValidationError(w, fmt.Sprintf("radius_m must be greater than 0 and at most %d", service.MaxNearbyRadiusMeters))
+			return
+		}
+		util.RespondInternalError(w, "Failed to search nearby pins")
+		return
+	}
+
 	// 成功レスポンス
 	util.RespondJSON(w, http.StatusOK, pins)
 }
 
+// GetPinsInBounds はバウンディングボックス内のユーザーのPinを取得します
+// GET /api/pins/within?min_lat=&min_lng=&max_lat=&max_lng=&limit=
+// min_lngがmax_lngより大きい場合は日付変更線をまたぐ範囲として扱います
+// GET /api/pins と同じ設備属性・営業中の絞り込みに対応します
+func (h *PinHandler) GetPinsInBounds(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// クエリパラメータのパース
+	bbox, err := parseBoundingBox(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	limit, err := util.ParseIntQuery(r, "limit", service.MaxBoundsLimit)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	filter, err := parsePinFilter(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// 範囲内のPinを取得
+	result, err := h.pinService.GetPinsInBounds(r.Context(), userID, bbox, filter, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBoundingBox) {
+			util.RespondValidationError(w, "Invalid bounding box")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get pins")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, result)
+}
+
+// GetPinClusters は地図の縮小表示用にバウンディングボックス内のPinをクラスタリングして取得します
+// GET /api/pins/clusters?min_lat=&min_lng=&max_lat=&max_lng=&zoom=
+func (h *PinHandler) GetPinClusters(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// クエリパラメータのパース
+	bbox, err := parseBoundingBox(r)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+	zoom, err := util.ParseIntQuery(r, "zoom", -1)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	// クラスタの取得
+	clusters, err := h.pinService.GetPinClusters(r.Context(), userID, bbox, zoom)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBoundingBox) {
+			util.RespondValidationError(w, "Invalid bounding box")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidZoom) {
+			util.RespondValidationError(w, fmt.Sprintf("zoom must be between 0 and %d", service.MaxClusterZoom))
+			return
+		}
+		util.RespondInternalError(w, "Failed to get pin clusters")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, clusters)
+}
+
+// GetPinTile はPinのMapbox Vector Tileを取得します
+// GET /api/tiles/pins/{z}/{x}/{y}.mvt?user_id=
+// 公開範囲がpublicのPinと認証されたユーザー自身のPinのみを含みます
+// ETagによる条件付きリクエスト（If-None-Match）に対応します
+func (h *PinHandler) GetPinTile(w http.ResponseWriter, r *http.Request) {
+	// URLパラメータからタイル座標を取得
+	z, errZ := strconv.Atoi(chi.URLParam(r, "z"))
+	x, errX := strconv.Atoi(chi.URLParam(r, "x"))
+	y, errY := strconv.Atoi(chi.URLParam(r, "y"))
+	if errZ != nil || errX != nil || errY != nil {
+		util.RespondValidationError(w, "Invalid tile coordinates")
+		return
+	}
+
+	// ユーザーによる絞り込み（任意）
+	filterUserID := r.URL.Query().Get("user_id")
+	if filterUserID != "" {
+		if err := util.ValidateUUID(filterUserID); err != nil {
+			util.RespondValidationError(w, "user_id must be a valid UUID")
+			return
+		}
+	}
+
+	// タイルの取得（閲覧できるのは公開Pinと自分のPinのみ）
+	viewerID, _ := middleware.GetUserIDFromContext(r.Context())
+	tile, err := h.pinService.GetPinTile(r.Context(), z, x, y, viewerID, filterUserID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTile) {
+			util.RespondValidationError(w, "Invalid tile coordinates")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get pin tile")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondWithETag(w, r, util.MVTContentType, tile)
+}
+
 // GetPin は指定されたPinを取得します
 // GET /api/pins/:id
+// 他のユーザーのPinは公開範囲がpublicの場合のみ取得でき、それ以外は404を返します
 // 要件: 6.1, 7.1
 func (h *PinHandler) GetPin(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
 	// URLパラメータからPin IDを取得
 	pinID := chi.URLParam(r, "id")
 	if pinID == "" {
@@ -159,7 +593,47 @@ func (h *PinHandler) GetPin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Pinを取得
-	pin, err := h.pinService.GetPin(r.Context(), pinID)
+	pin, err := h.pinService.GetPin(r.Context(), pinID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get pin")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, pin)
+}
+
+// GetPublicPins は公開範囲がpublicのPinを新しい順に取得します（認証不要）
+// GET /api/public/pins?limit=&cursor=
+func (h *PinHandler) GetPublicPins(w http.ResponseWriter, r *http.Request) {
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	page, err := h.pinService.GetPublicPinPage(r.Context(), r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get public pins")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// GetSharedPin は共有リンク用トークンでPinを取得します（認証不要）
+// GET /api/public/pins/shared/:token
+func (h *PinHandler) GetSharedPin(w http.ResponseWriter, r *http.Request) {
+	pin, err := h.pinService.GetSharedPin(r.Context(), chi.URLParam(r, "token"))
 	if err != nil {
 		if errors.Is(err, service.ErrPinNotFound) {
 			util.RespondNotFound(w, "Pin not found")
@@ -212,3 +686,111 @@ func (h *PinHandler) DeletePin(w http.ResponseWriter, r *http.Request) {
 		"message": "Pin deleted successfully",
 	})
 }
+
+// parseBoundingBox はクエリパラメータからバウンディングボックスをパースして検証します
+func parseBoundingBox(r *http.Request) (model.BoundingBox, error) {
+	var bbox model.BoundingBox
+	var err error
+
+	if bbox.MinLat, err = util.ParseFloatQuery(r, "min_lat"); err != nil {
+		return bbox, err
+	}
+	if bbox.MinLng, err = util.ParseFloatQuery(r, "min_lng"); err != nil {
+		return bbox, err
+	}
+	if bbox.MaxLat, err = util.ParseFloatQuery(r, "max_lat"); err != nil {
+		return bbox, err
+	}
+	if bbox.MaxLng, err = util.ParseFloatQuery(r, "max_lng"); err != nil {
+		return bbox, err
+	}
+
+	if err := util.ValidateCoordinates(bbox.MinLat, bbox.MinLng); err != nil {
+		return bbox, err
+	}
+	if err := util.ValidateCoordinates(bbox.MaxLat, bbox.MaxLng); err != nil {
+		return bbox, err
+	}
+
+	return bbox, nil
+}
+
+// readImportFile はmultipartリクエストのfileパートを逐次パースしてインポート対象の行を返します
+// ファイル全体をメモリやディスクに展開せずに読み取ります
+func readImportFile(r *http.Request) ([]*model.PinImportRow, error) {
+	rows := make([]*model.PinImportRow, 0)
+	err := util.ReadMultipartFile(r, "file", func(part *multipart.Part) error {
+		// ファイル形式の判定
+		format, err := geofile.DetectFormat(part.FileName())
+		if f := r.URL.Query().Get("format"); f != "" {
+			format, err = geofile.ParseFormat(f)
+		}
+		if err != nil {
+			return errors.New("format must be one of gpx, kml, geojson")
+		}
+
+		return geofile.Parse(format, part, func(p geofile.Point) error {
+			if len(rows) >= service.MaxImportRows {
+				return service.ErrTooManyImportRows
+			}
+			rows = append(rows, &model.PinImportRow{
+				Index:     p.Index,
+				Name:      p.Name,
+				Latitude:  p.Latitude,
+				Longitude: p.Longitude,
+				Err:       p.Err,
+				Skip:      errors.Is(p.Err, geofile.ErrUnsupportedGeometry),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// parsePinFilter はクエリパラメータから設備属性と営業中による絞り込み条件を取得します
+// atはopen_now=trueと組み合わせて、営業中かどうかを評価する時刻を指定します
+func parsePinFilter(r *http.Request) (model.PinFilter, error) {
+	var filter model.PinFilter
+	params := []struct {
+		name  string
+		value **bool
+	}{
+		{"wheelchair_accessible", &filter.WheelchairAccessible},
+		{"baby_changing", &filter.BabyChanging},
+		{"gender_neutral", &filter.GenderNeutral},
+		{"washlet", &filter.Washlet},
+		{"paid", &filter.Paid},
+	}
+
+	for _, param := range params {
+		value, err := util.ParseBoolQuery(r, param.name)
+		if err != nil {
+			return model.PinFilter{}, err
+		}
+		*param.value = value
+	}
+
+	openNow, err := util.ParseBoolQuery(r, "open_now")
+	if err != nil {
+		return model.PinFilter{}, err
+	}
+	at, err := util.ParseTimeQuery(r, "at")
+	if err != nil {
+		return model.PinFilter{}, err
+	}
+	if openNow != nil && *openNow {
+		if at == nil {
+			now := time.Now()
+			at = &now
+		}
+		filter.OpenAt = at
+	} else if at != nil {
+		return model.PinFilter{}, errors.New("at requires open_now=true")
+	}
+
+	return filter, nil
+}
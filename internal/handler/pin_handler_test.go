@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,26 +15,40 @@ import (
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // setupPinTestRouter はPin用のテストルーターをセットアップします
-func setupPinTestRouter(pinHandler *PinHandler) *chi.Mux {
+func setupPinTestRouter(authMiddleware func(http.Handler) http.Handler, pinHandler *PinHandler) *chi.Mux {
 	r := chi.NewRouter()
 	
 	r.Route("/api/pins", func(r chi.Router) {
 		// 認証が必要なエンドポイント
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware)
+			r.Use(authMiddleware)
 			r.Post("/", pinHandler.CreatePin)
+			r.Post("/import", pinHandler.ImportPins)
 			r.Get("/", pinHandler.GetPins)
+			r.Get("/nearby", pinHandler.SearchNearbyPins)
+			r.Get("/within", pinHandler.GetPinsInBounds)
+			r.Get("/clusters", pinHandler.GetPinClusters)
 			r.Get("/{id}", pinHandler.GetPin)
 			r.Put("/{id}", pinHandler.UpdatePin)
+			r.Put("/{id}/visibility", pinHandler.UpdatePinVisibility)
+			r.Get("/{id}/history", pinHandler.GetPinHistory)
+			r.Post("/{id}/revert/{revision}", pinHandler.RevertPin)
 			r.Delete("/{id}", pinHandler.DeletePin)
 		})
 	})
+
+	// 認証不要の公開エンドポイント
+	r.Route("/api/public", func(r chi.Router) {
+		r.Get("/pins", pinHandler.GetPublicPins)
+		r.Get("/pins/shared/{token}", pinHandler.GetSharedPin)
+	})
 	
 	return r
 }
@@ -48,10 +64,12 @@ func TestPinHandler_CreatePin(t *testing.T) {
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	pinHandler := NewPinHandler(pinService)
-	router := setupPinTestRouter(pinHandler)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -64,8 +82,7 @@ func TestPinHandler_CreatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreatePinRequest{
 			Name:      "トイレA",
@@ -121,8 +138,7 @@ func TestPinHandler_CreatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreatePinRequest{
 			Name:      "トイレA",
@@ -149,8 +165,7 @@ func TestPinHandler_CreatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.CreatePinRequest{
 			Name:      "",
@@ -168,6 +183,115 @@ func TestPinHandler_CreatePin(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("成功: 設備属性付きでPin作成", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		body := []byte(`{
+			"name": "駅前公衆トイレ",
+			"latitude": 35.6895,
+			"longitude": 139.6917,
+			"wheelchair_accessible": true,
+			"baby_changing": false,
+			"washlet": true,
+			"paid": true,
+			"fee_amount": 100,
+			"opening_hours": "Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00",
+			"timezone": "Asia/Tokyo"
+		}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/pins/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var pin model.Pin
+		err = json.Unmarshal(w.Body.Bytes(), &pin)
+		require.NoError(t, err)
+		require.NotNil(t, pin.WheelchairAccessible)
+		assert.True(t, *pin.WheelchairAccessible)
+		require.NotNil(t, pin.BabyChanging)
+		assert.False(t, *pin.BabyChanging)
+		assert.Nil(t, pin.GenderNeutral)
+		require.NotNil(t, pin.FeeAmount)
+		assert.Equal(t, 100, *pin.FeeAmount)
+		require.NotNil(t, pin.OpeningHours)
+		assert.Equal(t, "Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00", *pin.OpeningHours)
+		require.NotNil(t, pin.Timezone)
+		assert.Equal(t, "Asia/Tokyo", *pin.Timezone)
+
+		// 保存された値を確認
+		saved, err := pinService.GetPin(context.Background(), pin.ID, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pin.PinAttributes, saved.PinAttributes)
+	})
+
+	t.Run("エラー: 不正な営業時間", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		body := []byte(`{"name": "トイレA", "latitude": 35.6895, "longitude": 139.6917, "opening_hours": "weekdays 8am-8pm", "timezone": "Asia/Tokyo"}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/pins/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// 営業時間はPinの現地時間で評価するため、タイムゾーンが必須
+		body = []byte(`{"name": "トイレA", "latitude": 35.6895, "longitude": 139.6917, "opening_hours": "Mo-Su 08:00-20:00"}`)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/pins/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 無料のPinに料金を指定", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		body := []byte(`{"name": "トイレA", "latitude": 35.6895, "longitude": 139.6917, "paid": false, "fee_amount": 100}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/pins/", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 // TestPinHandler_UpdatePin はPin更新エンドポイントのテスト
@@ -181,10 +305,12 @@ func TestPinHandler_UpdatePin(t *testing.T) {
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	pinHandler := NewPinHandler(pinService)
-	router := setupPinTestRouter(pinHandler)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -201,8 +327,7 @@ func TestPinHandler_UpdatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.UpdatePinRequest{
 			Name:      "トイレB",
@@ -230,6 +355,77 @@ func TestPinHandler_UpdatePin(t *testing.T) {
 		assert.Equal(t, 139.7000, updatedPin.Longitude)
 	})
 
+	t.Run("成功: 省略した設備属性は変更されない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		yes := true
+		fee := 100
+		hours := "Mo-Fr 08:00-20:00"
+		timezone := "Asia/Tokyo"
+		pin, err := pinService.CreatePin(context.Background(), user.ID, "トイレA", 35.6895, 139.6917, model.PinAttributes{
+			Washlet:      &yes,
+			Paid:         &yes,
+			FeeAmount:    &fee,
+			OpeningHours: &hours,
+			Timezone:     &timezone,
+		})
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		// washletはnullで不明に戻し、それ以外の設備属性は省略する
+		body := []byte(`{"name": "トイレB", "latitude": 35.7, "longitude": 139.7, "baby_changing": true, "washlet": null}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/pins/"+pin.ID, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		saved, err := pinService.GetPin(context.Background(), pin.ID, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, saved.BabyChanging)
+		assert.True(t, *saved.BabyChanging)
+		assert.Nil(t, saved.Washlet)
+		assert.Equal(t, pin.Paid, saved.Paid)
+		assert.Equal(t, pin.FeeAmount, saved.FeeAmount)
+		assert.Equal(t, pin.OpeningHours, saved.OpeningHours)
+		assert.Equal(t, pin.Timezone, saved.Timezone)
+	})
+
+	t.Run("エラー: 変更後の設備属性の組み合わせが不正", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		hours := "Mo-Fr 08:00-20:00"
+		timezone := "Asia/Tokyo"
+		pin, err := pinService.CreatePin(context.Background(), user.ID, "トイレA", 35.6895, 139.6917, model.PinAttributes{
+			OpeningHours: &hours,
+			Timezone:     &timezone,
+		})
+		require.NoError(t, err)
+
+		token := loginToken(t, authService, user.Email, "password123")
+
+		// 営業時間を残したままタイムゾーンだけを消去することはできない
+		body := []byte(`{"name": "トイレA", "latitude": 35.6895, "longitude": 139.6917, "timezone": null}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/pins/"+pin.ID, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("エラー: 他のユーザーのPinを更新しようとする", func(t *testing.T) {
 		defer testDB.CleanupData()
 
@@ -246,8 +442,7 @@ func TestPinHandler_UpdatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// ユーザー2のトークンを生成
-		token, _, err := authService.Login(context.Background(), user2.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user2.Email, "password123")
 
 		reqBody := model.UpdatePinRequest{
 			Name:      "トイレB",
@@ -280,8 +475,7 @@ func TestPinHandler_UpdatePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		reqBody := model.UpdatePinRequest{
 			Name:      "トイレB",
@@ -312,10 +506,12 @@ func TestPinHandler_GetPins(t *testing.T) {
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	pinHandler := NewPinHandler(pinService)
-	router := setupPinTestRouter(pinHandler)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -334,8 +530,7 @@ func TestPinHandler_GetPins(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodGet, "/api/pins/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -345,10 +540,12 @@ func TestPinHandler_GetPins(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var pins []model.Pin
-		err = json.Unmarshal(w.Body.Bytes(), &pins)
+		var page model.PinPage
+		err = json.Unmarshal(w.Body.Bytes(), &page)
 		require.NoError(t, err)
+		pins := page.Items
 		assert.Len(t, pins, 2)
+		assert.Empty(t, page.NextCursor)
 		
 		// Pin IDの確認
 		pinIDs := []string{pins[0].ID, pins[1].ID}
@@ -356,7 +553,166 @@ func TestPinHandler_GetPins(t *testing.T) {
 		assert.Contains(t, pinIDs, pin2.ID)
 	})
 
-	t.Run("成功: Pinが0件の場合", func(t *testing.T) {
+	t.Run("成功: 設備属性で絞り込み", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 設備属性の異なるPinを作成
+		yes, no := true, false
+		accessible, err := pinService.CreatePin(context.Background(), user.ID, "バリアフリー", 35.6895, 139.6917,
+			model.PinAttributes{WheelchairAccessible: &yes, Paid: &no})
+		require.NoError(t, err)
+		_, err = pinService.CreatePin(context.Background(), user.ID, "段差あり", 35.7000, 139.7000,
+			model.PinAttributes{WheelchairAccessible: &no, Paid: &no})
+		require.NoError(t, err)
+		_, err = pinService.CreatePin(context.Background(), user.ID, "不明", 35.7100, 139.7100, model.PinAttributes{})
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/?wheelchair_accessible=true&paid=false", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page model.PinPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, accessible.ID, page.Items[0].ID)
+
+		// 不正な真偽値
+		req = httptest.NewRequest(http.MethodGet, "/api/pins/?washlet=maybe", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("成功: 営業中のPinで絞り込み", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 営業時間の異なるPinを作成
+		daytime := "Mo-Su 08:00-20:00"
+		nighttime := "Mo-Su 22:00-06:00"
+		tokyo := "Asia/Tokyo"
+		newYork := "America/New_York"
+		day, err := pinService.CreatePin(context.Background(), user.ID, "日中", 35.6895, 139.6917,
+			model.PinAttributes{OpeningHours: &daytime, Timezone: &tokyo})
+		require.NoError(t, err)
+		night, err := pinService.CreatePin(context.Background(), user.ID, "深夜", 35.7000, 139.7000,
+			model.PinAttributes{OpeningHours: &nighttime, Timezone: &tokyo})
+		require.NoError(t, err)
+		_, err = pinService.CreatePin(context.Background(), user.ID, "不明", 35.7100, 139.7100, model.PinAttributes{})
+		require.NoError(t, err)
+		// タイムゾーンを指定したPinはその現地時間で評価する
+		ny, err := pinService.CreatePin(context.Background(), user.ID, "ニューヨーク", 35.7200, 139.7200,
+			model.PinAttributes{OpeningHours: &daytime, Timezone: &newYork})
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		get := func(query string) (int, []*model.Pin) {
+			req := httptest.NewRequest(http.MethodGet, "/api/pins/?"+query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var page model.PinPage
+			if w.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+			}
+			return w.Code, page.Items
+		}
+
+		// 日本時間の正午（ニューヨークでは前日の22:00）
+		code, pins := get("open_now=true&at=2024-01-01T12:00:00%2B09:00")
+		assert.Equal(t, http.StatusOK, code)
+		require.Len(t, pins, 1)
+		assert.Equal(t, day.ID, pins[0].ID)
+
+		// 日本時間の深夜2時（日付をまたぐ時間帯）、ニューヨークでは正午
+		code, pins = get("open_now=true&at=2024-01-01T17:00:00Z")
+		assert.Equal(t, http.StatusOK, code)
+		require.Len(t, pins, 2)
+		assert.ElementsMatch(t, []string{night.ID, ny.ID}, []string{pins[0].ID, pins[1].ID})
+
+		// 不正な日時
+		code, _ = get("open_now=true&at=tomorrow")
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		// open_nowを指定しないat
+		code, _ = get("at=2024-01-01T12:00:00Z")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("成功: カーソルで全ページを重複なく取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinの作成
+		created := map[string]bool{}
+		for i := 0; i < 5; i++ {
+			pin, err := helper.CreateTestPin(user.ID, fmt.Sprintf("トイレ%d", i), 35.6895, 139.6917)
+			require.NoError(t, err)
+			created[pin.ID] = true
+		}
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		seen := map[string]bool{}
+		cursor := ""
+		pages := 0
+		for {
+			url := "/api/pins/?limit=2"
+			if cursor != "" {
+				url += "&cursor=" + cursor
+			}
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var page model.PinPage
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+			assert.LessOrEqual(t, len(page.Items), 2)
+			for _, pin := range page.Items {
+				assert.False(t, seen[pin.ID], "duplicate pin %s", pin.ID)
+				seen[pin.ID] = true
+			}
+
+			pages++
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+			require.Less(t, pages, 5)
+		}
+
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, created, seen)
+	})
+
+	t.Run("失敗: 不正なカーソル", func(t *testing.T) {
 		defer testDB.CleanupData()
 
 		// テストユーザーの作成
@@ -364,9 +720,27 @@ func TestPinHandler_GetPins(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/?cursor=invalid!", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("成功: Pinが0件の場合", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
 		require.NoError(t, err)
 
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
 		req := httptest.NewRequest(http.MethodGet, "/api/pins/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
@@ -375,10 +749,11 @@ func TestPinHandler_GetPins(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var pins []model.Pin
-		err = json.Unmarshal(w.Body.Bytes(), &pins)
+		var page model.PinPage
+		err = json.Unmarshal(w.Body.Bytes(), &page)
 		require.NoError(t, err)
-		assert.Len(t, pins, 0)
+		assert.NotNil(t, page.Items)
+		assert.Len(t, page.Items, 0)
 	})
 }
 
@@ -393,10 +768,12 @@ func TestPinHandler_GetPin(t *testing.T) {
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	pinHandler := NewPinHandler(pinService)
-	router := setupPinTestRouter(pinHandler)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -413,8 +790,7 @@ func TestPinHandler_GetPin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodGet, "/api/pins/"+pin.ID, nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -441,8 +817,7 @@ func TestPinHandler_GetPin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodGet, "/api/pins/nonexistent-id", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -452,6 +827,64 @@ func TestPinHandler_GetPin(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
+
+	t.Run("エラー: 他のユーザーの非公開Pinは存在しないものとして扱う", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+
+		// 他のユーザーのPin（デフォルトはprivate）
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, other.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/"+pin.ID, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("成功: 他のユーザーの公開Pinを取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+
+		// 公開Pinの作成
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		_, err = pinService.SetPinVisibility(context.Background(), pin.ID, owner.ID, model.PinVisibilityPublic)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, other.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/"+pin.ID, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var retrievedPin model.Pin
+		err = json.Unmarshal(w.Body.Bytes(), &retrievedPin)
+		require.NoError(t, err)
+		assert.Equal(t, pin.ID, retrievedPin.ID)
+		assert.Equal(t, model.PinVisibilityPublic, retrievedPin.Visibility)
+	})
 }
 
 // TestPinHandler_DeletePin はPin削除エンドポイントのテスト
@@ -465,10 +898,12 @@ func TestPinHandler_DeletePin(t *testing.T) {
 	// リポジトリとサービスの初期化
 	userRepo := repository.NewUserRepository(testDB.DB)
 	pinRepo := repository.NewPinRepository(testDB.DB)
-	authService := service.NewAuthService(userRepo)
-	pinService := service.NewPinService(pinRepo)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
 	pinHandler := NewPinHandler(pinService)
-	router := setupPinTestRouter(pinHandler)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
 
 	// テストヘルパーの作成
 	helper := database.NewTestHelper(testDB)
@@ -485,8 +920,7 @@ func TestPinHandler_DeletePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/pins/"+pin.ID, nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -503,7 +937,7 @@ func TestPinHandler_DeletePin(t *testing.T) {
 		assert.Contains(t, resp["message"], "deleted successfully")
 
 		// Pinが削除されたことを確認
-		_, err = pinService.GetPin(context.Background(), pin.ID)
+		_, err = pinService.GetPin(context.Background(), pin.ID, user.ID)
 		assert.Error(t, err)
 	})
 
@@ -523,8 +957,7 @@ func TestPinHandler_DeletePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// ユーザー2のトークンを生成
-		token, _, err := authService.Login(context.Background(), user2.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user2.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/pins/"+pin.ID, nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -549,8 +982,7 @@ func TestPinHandler_DeletePin(t *testing.T) {
 		require.NoError(t, err)
 
 		// トークンの生成
-		token, _, err := authService.Login(context.Background(), user.Email, "password123")
-		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/pins/nonexistent-id", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -561,3 +993,746 @@ func TestPinHandler_DeletePin(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
+
+// TestPinHandler_SearchNearbyPins は近傍Pin検索エンドポイントのテスト
+func TestPinHandler_SearchNearbyPins(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	t.Run("成功: 半径内のPinを近い順に取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinの作成（東京駅、有楽町、新宿）
+		far, err := helper.CreateTestPin(user.ID, "有楽町", 35.6751, 139.7630)
+		require.NoError(t, err)
+		near, err := helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(user.ID, "新宿", 35.6896, 139.7006)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/nearby?lat=35.6812&lng=139.7671&radius_m=2000", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var pins []model.NearbyPin
+		err = json.Unmarshal(w.Body.Bytes(), &pins)
+		require.NoError(t, err)
+		require.Len(t, pins, 2)
+		assert.Equal(t, near.ID, pins[0].ID)
+		assert.Equal(t, far.ID, pins[1].ID)
+		assert.InDelta(t, 0, pins[0].DistanceMeters, 1)
+		assert.Greater(t, pins[1].DistanceMeters, pins[0].DistanceMeters)
+	})
+
+	t.Run("エラー: 半径が指定されていない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/nearby?lat=35.6812&lng=139.7671", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 無効な緯度", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/nearby?lat=91&lng=139.7671&radius_m=1000", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 数値でない検索半径", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		token := loginToken(t, authService, user.Email, "password123")
+
+		for _, radius := range []string{"NaN", "Inf", "-Inf"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/pins/nearby?lat=35.6812&lng=139.7671&radius_m="+radius, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, radius)
+		}
+	})
+}
+
+// TestPinHandler_GetPinsInBounds はバウンディングボックス検索エンドポイントのテスト
+func TestPinHandler_GetPinsInBounds(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	t.Run("成功: 範囲内のPinのみ取得", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinの作成
+		inside, err := helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(user.ID, "大阪駅", 34.7025, 135.4959)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/within?min_lat=35.5&min_lng=139.5&max_lat=35.8&max_lng=139.9", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result model.PinsInBounds
+		err = json.Unmarshal(w.Body.Bytes(), &result)
+		require.NoError(t, err)
+		require.Len(t, result.Pins, 1)
+		assert.Equal(t, inside.ID, result.Pins[0].ID)
+		assert.False(t, result.Truncated)
+	})
+
+	t.Run("成功: 日付変更線をまたぐ範囲", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 日付変更線の東西にPinを作成
+		east, err := helper.CreateTestPin(user.ID, "東側", -17.0, 179.5)
+		require.NoError(t, err)
+		west, err := helper.CreateTestPin(user.ID, "西側", -17.0, -179.5)
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(user.ID, "範囲外", -17.0, 0)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/within?min_lat=-18&min_lng=179&max_lat=-16&max_lng=-179", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result model.PinsInBounds
+		err = json.Unmarshal(w.Body.Bytes(), &result)
+		require.NoError(t, err)
+		require.Len(t, result.Pins, 2)
+		pinIDs := []string{result.Pins[0].ID, result.Pins[1].ID}
+		assert.Contains(t, pinIDs, east.ID)
+		assert.Contains(t, pinIDs, west.ID)
+	})
+
+	t.Run("成功: 最大件数を超えた場合は切り詰める", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// テストPinの作成
+		for i := 0; i < 3; i++ {
+			_, err := helper.CreateTestPin(user.ID, "トイレ", 35.68+float64(i)*0.001, 139.76)
+			require.NoError(t, err)
+		}
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/within?min_lat=35&min_lng=139&max_lat=36&max_lng=140&limit=2", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result model.PinsInBounds
+		err = json.Unmarshal(w.Body.Bytes(), &result)
+		require.NoError(t, err)
+		assert.Len(t, result.Pins, 2)
+		assert.True(t, result.Truncated)
+	})
+
+	t.Run("エラー: 南端が北端より大きい", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/within?min_lat=36&min_lng=139&max_lat=35&max_lng=140", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestPinHandler_GetPinClusters はPinクラスタリングエンドポイントのテスト
+func TestPinHandler_GetPinClusters(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	t.Run("成功: 近接するPinは集約され単独のPinは展開される", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 東京周辺に2件、大阪に1件のPinを作成
+		_, err = helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(user.ID, "有楽町", 35.6751, 139.7630)
+		require.NoError(t, err)
+		osaka, err := helper.CreateTestPin(user.ID, "大阪駅", 34.7025, 135.4959)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/clusters?min_lat=30&min_lng=130&max_lat=40&max_lng=145&zoom=5", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var clusters []model.PinCluster
+		err = json.Unmarshal(w.Body.Bytes(), &clusters)
+		require.NoError(t, err)
+		require.Len(t, clusters, 2)
+
+		// 件数の多い順に並ぶ
+		assert.Equal(t, 2, clusters[0].Count)
+		assert.Nil(t, clusters[0].Pin)
+		assert.Equal(t, 1, clusters[1].Count)
+		require.NotNil(t, clusters[1].Pin)
+		assert.Equal(t, osaka.ID, clusters[1].Pin.ID)
+	})
+
+	t.Run("エラー: 無効なズームレベル", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pins/clusters?min_lat=30&min_lng=130&max_lat=40&max_lng=145&zoom=30", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// newImportRequest はインポート用のmultipartリクエストを作成します
+func newImportRequest(t *testing.T, token, filename, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/pins/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// TestPinHandler_ImportPins はPin一括インポートエンドポイントのテスト
+func TestPinHandler_ImportPins(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	t.Run("成功: GPXファイルから作成・スキップ・無効を判定", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 既存のPinを作成（重複としてスキップされる）
+		_, err = helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		gpx := `<?xml version="1.0"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="35.6812" lon="139.7671"><name>東京駅</name></wpt>
+  <wpt lat="35.6896" lon="139.7006"><name>新宿</name></wpt>
+  <wpt lat="95.0" lon="139.7006"><name>範囲外</name></wpt>
+</gpx>`
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, token, "toilets.gpx", gpx))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var report model.PinImportReport
+		err = json.Unmarshal(w.Body.Bytes(), &report)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Equal(t, 1, report.Invalid)
+		require.Len(t, report.Rows, 3)
+		assert.Equal(t, model.ImportStatusSkipped, report.Rows[0].Status)
+		assert.Equal(t, model.ImportStatusCreated, report.Rows[1].Status)
+		assert.NotEmpty(t, report.Rows[1].PinID)
+		assert.Equal(t, model.ImportStatusInvalid, report.Rows[2].Status)
+
+		// 作成されたPinを確認
+		pins, err := pinService.GetPinsByUser(context.Background(), user.ID, model.PinFilter{})
+		require.NoError(t, err)
+		assert.Len(t, pins, 2)
+	})
+
+	t.Run("エラー: 未対応のファイル形式", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, token, "toilets.txt", "hello"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 不正なGeoJSON", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, token, "toilets.geojson", `{"type": "FeatureCollection", "features": [`))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestPinHandler_PinVisibility はPinの公開範囲に関するエンドポイントのテスト
+func TestPinHandler_PinVisibility(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// setVisibility は公開範囲変更エンドポイントを呼び出します
+	setVisibility := func(t *testing.T, token, pinID, visibility string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(model.UpdatePinVisibilityRequest{Visibility: visibility})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/api/pins/"+pinID+"/visibility", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: 共有リンクで取得でき、非公開に戻すと無効になる", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := setVisibility(t, token, pin.ID, model.PinVisibilitySharedByLink)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var updated model.Pin
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, model.PinVisibilitySharedByLink, updated.Visibility)
+		require.NotNil(t, updated.ShareToken)
+		shareToken := *updated.ShareToken
+
+		// 共有リンクは認証なしで取得でき、トークンは返さない
+		req := httptest.NewRequest(http.MethodGet, "/api/public/pins/shared/"+shareToken, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var shared model.Pin
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &shared))
+		assert.Equal(t, pin.ID, shared.ID)
+		assert.Nil(t, shared.ShareToken)
+
+		// 非公開に戻すと共有リンクは無効になる
+		w = setVisibility(t, token, pin.ID, model.PinVisibilityPrivate)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/public/pins/shared/"+shareToken, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("成功: 公開フィードには公開Pinのみ含まれる", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		publicPin, err := helper.CreateTestPin(user.ID, "公開トイレ", 35.6895, 139.6917)
+		require.NoError(t, err)
+		sharedPin, err := helper.CreateTestPin(user.ID, "共有トイレ", 35.7000, 139.7000)
+		require.NoError(t, err)
+		_, err = helper.CreateTestPin(user.ID, "非公開トイレ", 35.7100, 139.7100)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		require.Equal(t, http.StatusOK, setVisibility(t, token, publicPin.ID, model.PinVisibilityPublic).Code)
+		require.Equal(t, http.StatusOK, setVisibility(t, token, sharedPin.ID, model.PinVisibilitySharedByLink).Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/public/pins", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page model.PinPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, publicPin.ID, page.Items[0].ID)
+	})
+
+	t.Run("エラー: 無効な公開範囲", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := setVisibility(t, token, pin.ID, "friends-only")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("エラー: 他のユーザーのPinの公開範囲は変更できない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, other.Email, "password123")
+
+		w := setVisibility(t, token, pin.ID, model.PinVisibilityPublic)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("エラー: メールアドレスが未確認のユーザーはPinを公開できない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		user, err := helper.CreateUnverifiedTestUser("unverified@example.com", "password123", "Unverified")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		w := setVisibility(t, token, pin.ID, model.PinVisibilityPublic)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		var errResp model.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+		assert.Equal(t, model.ErrCodeEmailNotVerified, errResp.Error.Code)
+
+		// 共有リンクでの公開はできる
+		w = setVisibility(t, token, pin.ID, model.PinVisibilitySharedByLink)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// 確認済みになると公開できる
+		_, err = testDB.DB.Exec("UPDATE users SET email_verified_at = NOW() WHERE id = $1", user.ID)
+		require.NoError(t, err)
+		w = setVisibility(t, token, pin.ID, model.PinVisibilityPublic)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// TestPinHandler_PinHistory はPinの編集履歴と取り消しのテスト
+func TestPinHandler_PinHistory(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupPinTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// send はJSONボディ付きのリクエストを送信します
+	send := func(method, url, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, url, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: 更新ごとに履歴が記録され、取り消せる", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(user.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		url := "/api/pins/" + pin.ID
+
+		// 2回更新
+		w := send(http.MethodPut, url, token, model.UpdatePinRequest{Name: "トイレB", Latitude: 35.7, Longitude: 139.7})
+		require.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodPut, url, token, model.UpdatePinRequest{Name: "間違えた名前", Latitude: 10, Longitude: 10})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// 履歴は新しい順
+		w = send(http.MethodGet, url+"/history", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.PinRevisionPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, 2, page.Items[0].Revision)
+		assert.Equal(t, "トイレB", page.Items[0].OldName)
+		assert.Equal(t, "間違えた名前", page.Items[0].NewName)
+		assert.Equal(t, user.ID, page.Items[0].EditorID)
+		assert.Equal(t, 1, page.Items[1].Revision)
+		assert.Equal(t, "トイレA", page.Items[1].OldName)
+		assert.InDelta(t, 35.6895, page.Items[1].OldLatitude, 0.0001)
+		assert.InDelta(t, 139.6917, page.Items[1].OldLongitude, 0.0001)
+
+		// リビジョン2を取り消すと更新前の状態に戻る
+		w = send(http.MethodPost, url+"/revert/2", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var reverted model.Pin
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reverted))
+		assert.Equal(t, "トイレB", reverted.Name)
+		assert.InDelta(t, 35.7, reverted.Latitude, 0.0001)
+		assert.InDelta(t, 139.7, reverted.Longitude, 0.0001)
+
+		// 取り消しも履歴に記録される
+		w = send(http.MethodGet, url+"/history?limit=1", token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, 3, page.Items[0].Revision)
+		assert.Equal(t, "トイレB", page.Items[0].NewName)
+		require.NotEmpty(t, page.NextCursor)
+
+		// 次のページはリビジョン番号の続きから
+		w = send(http.MethodGet, url+"/history?limit=1&cursor="+page.NextCursor, token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, 2, page.Items[0].Revision)
+	})
+
+	t.Run("エラー: 他のユーザーと存在しないリビジョン", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		url := "/api/pins/" + pin.ID
+		w := send(http.MethodPut, url, ownerToken, model.UpdatePinRequest{Name: "トイレB", Latitude: 35.7, Longitude: 139.7})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// 他のユーザーは履歴の取得も取り消しもできない
+		w = send(http.MethodGet, url+"/history", otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, url+"/revert/1", otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = send(http.MethodPost, url+"/revert/99", otherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// 存在しないリビジョンと不正なリビジョン番号
+		w = send(http.MethodPost, url+"/revert/99", ownerToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = send(http.MethodPost, url+"/revert/abc", ownerToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// ReviewHandler はレビュー関連のHTTPハンドラーを提供します
+type ReviewHandler struct {
+	reviewService service.ReviewService
+}
+
+// NewReviewHandler は新しいReviewHandlerインスタンスを作成します
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// CreateReview はPinにレビューを作成します
+// POST /api/pins/:id/reviews
+// 1人のユーザーは1つのPinに1件のみレビューでき、既に存在する場合は409を返します
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	// リクエストボディのパース
+	var req model.ReviewRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := validateReviewScores(req.ReviewScores); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(r.Context(), pinID, userID, req.ReviewScores)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		if errors.Is(err, service.ErrReviewAlreadyExists) {
+			util.RespondConflict(w, "You have already reviewed this pin")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidReview) {
+			util.RespondValidationError(w, "Invalid review")
+			return
+		}
+		util.RespondInternalError(w, "Failed to create review")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusCreated, review)
+}
+
+// GetReviews はPinのレビュー一覧を新しい順に取得します
+// GET /api/pins/:id/reviews?limit=&cursor=
+// レスポンスのnext_cursorをcursorに指定すると次のページを取得できます
+func (h *ReviewHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	page, err := h.reviewService.GetReviewPage(r.Context(), pinID, userID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get reviews")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// UpdateReview は自分のレビューを更新します
+// PUT /api/pins/:id/reviews/:reviewId
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDとレビューIDを取得
+	pinID := chi.URLParam(r, "id")
+	reviewID := chi.URLParam(r, "reviewId")
+	if pinID == "" || reviewID == "" {
+		util.RespondValidationError(w, "Pin ID and review ID are required")
+		return
+	}
+
+	// リクエストボディのパース
+	var req model.ReviewRequest
+	if err := util.ParseJSONBody(r, &req); err != nil {
+		util.RespondValidationError(w, "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if err := validateReviewScores(req.ReviewScores); err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	review, err := h.reviewService.UpdateReview(r.Context(), pinID, reviewID, userID, req.ReviewScores)
+	if err != nil {
+		if errors.Is(err, service.ErrReviewNotFound) {
+			util.RespondNotFound(w, "Review not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedReviewAccess) {
+			util.RespondForbidden(w, "You don't have permission to update this review")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidReview) {
+			util.RespondValidationError(w, "Invalid review")
+			return
+		}
+		util.RespondInternalError(w, "Failed to update review")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, review)
+}
+
+// DeleteReview は自分のレビューを削除します
+// DELETE /api/pins/:id/reviews/:reviewId
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDとレビューIDを取得
+	pinID := chi.URLParam(r, "id")
+	reviewID := chi.URLParam(r, "reviewId")
+	if pinID == "" || reviewID == "" {
+		util.RespondValidationError(w, "Pin ID and review ID are required")
+		return
+	}
+
+	err := h.reviewService.DeleteReview(r.Context(), pinID, reviewID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrReviewNotFound) {
+			util.RespondNotFound(w, "Review not found")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedReviewAccess) {
+			util.RespondForbidden(w, "You don't have permission to delete this review")
+			return
+		}
+		util.RespondInternalError(w, "Failed to delete review")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Review deleted successfully",
+	})
+}
+
+// validateReviewScores はリクエストのスコアとコメントを検証します
+func validateReviewScores(scores model.ReviewScores) error {
+	fields := []struct {
+		name  string
+		value int
+	}{
+		{"cleanliness", scores.Cleanliness},
+		{"crowding", scores.Crowding},
+		{"paper_availability", scores.PaperAvailability},
+	}
+	for _, field := range fields {
+		if err := util.ValidateScore(field.value, model.MaxReviewScore, field.name); err != nil {
+			return err
+		}
+	}
+	return util.ValidateMaxLength(scores.Comment, model.MaxReviewCommentLength, "comment")
+}
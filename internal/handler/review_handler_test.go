@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupReviewTestRouter はレビュー用のテストルーターをセットアップします
+func setupReviewTestRouter(authMiddleware func(http.Handler) http.Handler, pinHandler *PinHandler, reviewHandler *ReviewHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/pins", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/{id}", pinHandler.GetPin)
+		r.Get("/{id}/reviews", reviewHandler.GetReviews)
+		r.Post("/{id}/reviews", reviewHandler.CreateReview)
+		r.Put("/{id}/reviews/{reviewId}", reviewHandler.UpdateReview)
+		r.Delete("/{id}/reviews/{reviewId}", reviewHandler.DeleteReview)
+	})
+
+	return r
+}
+
+// TestReviewHandler はレビューエンドポイントのテスト
+func TestReviewHandler(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	reviewRepo := repository.NewReviewRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	reviewService := service.NewReviewService(reviewRepo, pinRepo)
+	router := setupReviewTestRouter(middleware.NewAuthMiddleware(keySet, store), NewPinHandler(pinService), NewReviewHandler(reviewService))
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// send はJSONボディ付きのリクエストを送信します
+	send := func(method, url, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := httptest.NewRequest(method, url, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("成功: レビューの作成・更新・削除と集計", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		_, err = pinService.SetPinVisibility(context.Background(), pin.ID, owner.ID, model.PinVisibilityPublic)
+		require.NoError(t, err)
+
+		// トークンの生成
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		url := "/api/pins/" + pin.ID + "/reviews"
+
+		// 2人のユーザーがレビューを作成
+		w := send(http.MethodPost, url, ownerToken, model.ReviewScores{Cleanliness: 5, Crowding: 4, PaperAvailability: 5, Comment: " きれい "})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var mine model.Review
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &mine))
+		assert.Equal(t, "きれい", mine.Comment)
+
+		w = send(http.MethodPost, url, otherToken, model.ReviewScores{Cleanliness: 3, Crowding: 2, PaperAvailability: 1})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var others model.Review
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &others))
+
+		// 同じユーザーの2件目は作成できない
+		w = send(http.MethodPost, url, otherToken, model.ReviewScores{Cleanliness: 1, Crowding: 1, PaperAvailability: 1})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// 他のユーザーのレビューは更新できない
+		w = send(http.MethodPut, url+"/"+others.ID, ownerToken, model.ReviewScores{Cleanliness: 1, Crowding: 1, PaperAvailability: 1})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// 自分のレビューを更新
+		w = send(http.MethodPut, url+"/"+others.ID, otherToken, model.ReviewScores{Cleanliness: 4, Crowding: 2, PaperAvailability: 1})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// Pinのレスポンスに集計が含まれる
+		w = send(http.MethodGet, "/api/pins/"+pin.ID, otherToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var got model.Pin
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, 2, got.Ratings.Count)
+		require.NotNil(t, got.Ratings.Cleanliness.Mean)
+		assert.InDelta(t, 4.5, *got.Ratings.Cleanliness.Mean, 0.001)
+		assert.Equal(t, [5]int{0, 0, 0, 1, 1}, got.Ratings.Cleanliness.Histogram)
+		assert.Equal(t, [5]int{1, 0, 0, 0, 1}, got.Ratings.PaperAvailability.Histogram)
+
+		// 削除したレビューは一覧と集計に含まれない
+		w = send(http.MethodDelete, url+"/"+others.ID, otherToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodGet, url, ownerToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.ReviewPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, mine.ID, page.Items[0].ID)
+
+		w = send(http.MethodGet, "/api/pins/"+pin.ID, ownerToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, 1, got.Ratings.Count)
+
+		// 削除後は再びレビューを作成できる
+		w = send(http.MethodPost, url, otherToken, model.ReviewScores{Cleanliness: 2, Crowding: 2, PaperAvailability: 2})
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("エラー: 無効なスコアと非公開のPin", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		url := "/api/pins/" + pin.ID + "/reviews"
+
+		// 範囲外のスコア
+		w := send(http.MethodPost, url, ownerToken, model.ReviewScores{Cleanliness: 6, Crowding: 3, PaperAvailability: 3})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// 非公開のPinは他のユーザーからレビューできない
+		w = send(http.MethodPost, url, otherToken, model.ReviewScores{Cleanliness: 3, Crowding: 3, PaperAvailability: 3})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = send(http.MethodGet, url, otherToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
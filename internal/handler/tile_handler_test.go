@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTileTestRouter はタイル用のテストルーターをセットアップします
+func setupTileTestRouter(authMiddleware func(http.Handler) http.Handler, pinHandler *PinHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/tiles", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/pins/{z}/{x}/{y}.mvt", pinHandler.GetPinTile)
+	})
+
+	return r
+}
+
+// TestPinHandler_GetPinTile はPinのベクタータイル取得エンドポイントのテスト
+func TestPinHandler_GetPinTile(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	pinService := service.NewPinService(pinRepo, userRepo)
+	pinHandler := NewPinHandler(pinService)
+	router := setupTileTestRouter(middleware.NewAuthMiddleware(keySet, store), pinHandler)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	t.Run("成功: タイルとETagを取得し条件付きリクエストで304を返す", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// 東京駅のPinを作成（z=10のタイル 909/403 に含まれる）
+		_, err = helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/tiles/pins/10/909/403.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, util.MVTContentType, w.Header().Get("Content-Type"))
+		assert.NotEmpty(t, w.Body.Bytes())
+		etag := w.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		// 同じETagで再取得すると304
+		req = httptest.NewRequest(http.MethodGet, "/api/tiles/pins/10/909/403.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+	})
+
+	t.Run("成功: 削除済みのPinは含まれない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// Pinを作成して削除
+		pin, err := helper.CreateTestPin(user.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+		require.NoError(t, pinRepo.SoftDelete(context.Background(), pin.ID))
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/tiles/pins/10/909/403.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+	})
+
+	t.Run("成功: 他のユーザーの非公開Pinは含まれない", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		viewer, err := helper.CreateTestUser("viewer@example.com", "password123", "Viewer")
+		require.NoError(t, err)
+
+		// 他のユーザーの非公開Pin
+		pin, err := helper.CreateTestPin(owner.ID, "東京駅", 35.6812, 139.7671)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, viewer.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/tiles/pins/10/909/403.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+
+		// 公開すると含まれる
+		_, err = pinService.SetPinVisibility(context.Background(), pin.ID, owner.ID, model.PinVisibilityPublic)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/tiles/pins/10/909/403.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Body.Bytes())
+	})
+
+	t.Run("エラー: 範囲外のタイル座標", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーの作成
+		user, err := helper.CreateTestUser("test@example.com", "password123", "Test User")
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, user.Email, "password123")
+
+		req := httptest.NewRequest(http.MethodGet, "/api/tiles/pins/2/4/0.mvt", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
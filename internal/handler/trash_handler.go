@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// TrashHandler はゴミ箱（論理削除されたPin）関連のHTTPハンドラーを提供します
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+// NewTrashHandler は新しいTrashHandlerインスタンスを作成します
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash はゴミ箱にあるPinを削除日時の新しい順に取得します
+// GET /api/pins/trash?limit=&cursor=
+// レスポンスのnext_cursorをcursorに指定すると次のページを取得できます
+func (h *TrashHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	limit, err := util.ParseIntQuery(r, "limit", 0)
+	if err != nil {
+		util.RespondValidationError(w, err.Error())
+		return
+	}
+
+	page, err := h.trashService.GetTrashPage(r.Context(), userID, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			util.RespondValidationError(w, "Invalid cursor")
+			return
+		}
+		util.RespondInternalError(w, "Failed to get deleted pins")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, page)
+}
+
+// RestorePin はゴミ箱にあるPinを元に戻します
+// POST /api/pins/:id/restore
+func (h *TrashHandler) RestorePin(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	pin, err := h.trashService.RestorePin(r.Context(), pinID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found in trash")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPinAccess) {
+			util.RespondForbidden(w, "You don't have permission to restore this pin")
+			return
+		}
+		util.RespondInternalError(w, "Failed to restore pin")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, pin)
+}
+
+// PurgePin はゴミ箱にあるPinを完全に削除します
+// DELETE /api/pins/:id/purge
+// 完全に削除したPinは元に戻せません
+func (h *TrashHandler) PurgePin(w http.ResponseWriter, r *http.Request) {
+	// コンテキストからユーザーIDを取得
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		util.RespondUnauthorized(w, "Unauthorized")
+		return
+	}
+
+	// URLパラメータからPin IDを取得
+	pinID := chi.URLParam(r, "id")
+	if pinID == "" {
+		util.RespondValidationError(w, "Pin ID is required")
+		return
+	}
+
+	err := h.trashService.PurgePin(r.Context(), pinID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrPinNotFound) {
+			util.RespondNotFound(w, "Pin not found in trash")
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorizedPinAccess) {
+			util.RespondForbidden(w, "You don't have permission to purge this pin")
+			return
+		}
+		util.RespondInternalError(w, "Failed to purge pin")
+		return
+	}
+
+	// 成功レスポンス
+	util.RespondJSON(w, http.StatusOK, map[string]string{
+		"message": "Pin purged successfully",
+	})
+}
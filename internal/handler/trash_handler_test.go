@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/jpeg"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/database"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/middleware"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/service"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTrashTestRouter はゴミ箱用のテストルーターをセットアップします
+func setupTrashTestRouter(authMiddleware func(http.Handler) http.Handler, pinHandler *PinHandler, connectHandler *ConnectHandler, photoHandler *PhotoHandler, trashHandler *TrashHandler) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Route("/api/pins", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/trash", trashHandler.GetTrash)
+		r.Get("/{id}", pinHandler.GetPin)
+		r.Delete("/{id}", pinHandler.DeletePin)
+		r.Post("/{id}/restore", trashHandler.RestorePin)
+		r.Delete("/{id}/purge", trashHandler.PurgePin)
+		r.Get("/{id}/photos", photoHandler.GetPhotos)
+		r.Post("/{id}/photos", photoHandler.UploadPhoto)
+	})
+
+	r.Route("/api/connects", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/", connectHandler.GetConnects)
+	})
+
+	return r
+}
+
+// TestTrashHandler はゴミ箱エンドポイントのテスト
+func TestTrashHandler(t *testing.T) {
+	// テストデータベースのセットアップ
+	testDB, err := database.SetupTestDB()
+	require.NoError(t, err)
+	defer testDB.Teardown()
+
+	// ストレージはテストごとの一時ディレクトリを使用
+	photoStorage, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// リポジトリとサービスの初期化
+	userRepo := repository.NewUserRepository(testDB.DB)
+	pinRepo := repository.NewPinRepository(testDB.DB)
+	connectRepo := repository.NewConnectRepository(testDB.DB)
+	photoRepo := repository.NewPhotoRepository(testDB.DB)
+	store := revocation.NewMemoryStore()
+	keySet := newTestKeySet(t)
+	authService := service.NewAuthService(userRepo, repository.NewRefreshTokenRepository(testDB.DB), store, keySet)
+	trashService := service.NewTrashService(pinRepo, photoStorage)
+	router := setupTrashTestRouter(
+		middleware.NewAuthMiddleware(keySet, store),
+		NewPinHandler(service.NewPinService(pinRepo, userRepo)),
+		NewConnectHandler(service.NewConnectService(connectRepo, pinRepo)),
+		NewPhotoHandler(service.NewPhotoService(photoRepo, pinRepo, photoStorage)),
+		NewTrashHandler(trashService),
+	)
+
+	// テストヘルパーの作成
+	helper := database.NewTestHelper(testDB)
+
+	// send はボディ無しのリクエストを送信します
+	send := func(method, url, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// connectCount はユーザーに表示されるConnectの件数を返します
+	connectCount := func(token string) int {
+		w := send(http.MethodGet, "/api/connects", token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.ConnectPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		return len(page.Items)
+	}
+
+	t.Run("成功: ゴミ箱の一覧と復元", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPin、Connectの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		pin1, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(owner.ID, "トイレB", 35.6900, 139.7000)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(owner.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, owner.Email, "password123")
+
+		// 写真を追加
+		var img bytes.Buffer
+		require.NoError(t, jpeg.Encode(&img, image.NewRGBA(image.Rect(0, 0, 64, 64)), nil))
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "photo.jpg")
+		require.NoError(t, err)
+		_, err = part.Write(img.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest(http.MethodPost, "/api/pins/"+pin1.ID+"/photos", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		assert.Equal(t, 1, connectCount(token))
+
+		// 削除するとゴミ箱に入り、Connectは表示されなくなる
+		w = send(http.MethodDelete, "/api/pins/"+pin1.ID, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 0, connectCount(token))
+
+		w = send(http.MethodGet, "/api/pins/trash", token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.PinPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, pin1.ID, page.Items[0].ID)
+		assert.NotNil(t, page.Items[0].DeletedAt)
+
+		// 復元するとPin、写真、Connectが元に戻る
+		w = send(http.MethodPost, "/api/pins/"+pin1.ID+"/restore", token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var restored model.Pin
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+		assert.Equal(t, pin1.ID, restored.ID)
+		assert.Nil(t, restored.DeletedAt)
+
+		w = send(http.MethodGet, "/api/pins/"+pin1.ID+"/photos", token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var photos []*model.Photo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &photos))
+		assert.Len(t, photos, 1)
+
+		assert.Equal(t, 1, connectCount(token))
+
+		w = send(http.MethodGet, "/api/pins/trash", token)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Empty(t, page.Items)
+	})
+
+	t.Run("成功: 完全削除と保持期間を過ぎたPinの削除", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		pin1, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+		pin2, err := helper.CreateTestPin(owner.ID, "トイレB", 35.6900, 139.7000)
+		require.NoError(t, err)
+		_, err = helper.CreateTestConnect(owner.ID, pin1.ID, pin2.ID, true)
+		require.NoError(t, err)
+
+		// トークンの生成
+		token := loginToken(t, authService, owner.Email, "password123")
+
+		// ゴミ箱に入っていないPinは完全削除できない
+		w := send(http.MethodDelete, "/api/pins/"+pin1.ID+"/purge", token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = send(http.MethodDelete, "/api/pins/"+pin1.ID, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		w = send(http.MethodDelete, "/api/pins/"+pin1.ID+"/purge", token)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var count int
+		require.NoError(t, testDB.DB.Get(&count, "SELECT COUNT(*) FROM pins WHERE id = $1", pin1.ID))
+		assert.Equal(t, 0, count)
+		require.NoError(t, testDB.DB.Get(&count, "SELECT COUNT(*) FROM connect"))
+		assert.Equal(t, 0, count)
+
+		// 保持期間を過ぎたPinのみ削除される
+		w = send(http.MethodDelete, "/api/pins/"+pin2.ID, token)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		purged, err := trashService.PurgeExpired(context.Background(), service.DefaultTrashRetention)
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged)
+
+		_, err = testDB.DB.Exec("UPDATE pins SET deleted_at = NOW() - INTERVAL '31 days' WHERE id = $1", pin2.ID)
+		require.NoError(t, err)
+
+		purged, err = trashService.PurgeExpired(context.Background(), service.DefaultTrashRetention)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+	})
+
+	t.Run("エラー: 他のユーザーのPin", func(t *testing.T) {
+		defer testDB.CleanupData()
+
+		// テストユーザーとPinの作成
+		owner, err := helper.CreateTestUser("owner@example.com", "password123", "Owner")
+		require.NoError(t, err)
+		other, err := helper.CreateTestUser("other@example.com", "password123", "Other")
+		require.NoError(t, err)
+		pin, err := helper.CreateTestPin(owner.ID, "トイレA", 35.6895, 139.6917)
+		require.NoError(t, err)
+
+		// トークンの生成
+		ownerToken := loginToken(t, authService, owner.Email, "password123")
+		otherToken := loginToken(t, authService, other.Email, "password123")
+
+		w := send(http.MethodDelete, "/api/pins/"+pin.ID, ownerToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// 他のユーザーのゴミ箱には表示されず、復元・完全削除もできない
+		w = send(http.MethodGet, "/api/pins/trash", otherToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		var page model.PinPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Empty(t, page.Items)
+
+		w = send(http.MethodPost, "/api/pins/"+pin.ID+"/restore", otherToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodDelete, "/api/pins/"+pin.ID+"/purge", otherToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
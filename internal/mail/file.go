@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fileMailer はディレクトリに.emlファイルとして保存するMailerの実装
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer はdirにメールを.emlファイルとして保存するMailerを作成します
+// ディレクトリが存在しない場合は作成します。ファイル名は送信日時の順に並びます
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if dir == "" {
+		return nil, errors.New("mail directory is not set")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+// Send はメールをファイルに保存します
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	data, err := formatMessage(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(m.dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	return nil
+}
+
+// logMailer はログに出力するMailerの実装
+type logMailer struct {
+	from string
+}
+
+// NewLogMailer はメールを送信せずログに出力するMailerを作成します
+// 本文に確認用のトークンなどが含まれるため、本番環境では使用しないでください
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+// Send はメールをログに出力します
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	data, err := formatMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Mail (not sent):\n%s", data)
+	return nil
+}
@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+	"time"
+)
+
+// Message は送信するメールを表します
+type Message struct {
+	To      string
+	Subject string
+	Body    string // プレーンテキスト
+}
+
+// Mailer はメールを送信するインターフェースを定義します
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+const (
+	// DriverSMTP はSMTPサーバー経由で送信するドライバー
+	DriverSMTP = "smtp"
+	// DriverFile はディレクトリに.emlファイルとして保存するドライバー（ローカル開発・テスト用）
+	DriverFile = "file"
+	// DriverLog はログに出力するドライバー（ローカル開発用）
+	DriverLog = "log"
+)
+
+// Config はメール送信の設定
+type Config struct {
+	Driver string // smtp、file または log
+	From   string // 送信元アドレス
+	Dir    string // fileドライバーの保存先ディレクトリ
+	SMTP   SMTPConfig
+}
+
+// NewConfig は環境変数から設定を読み込む
+func NewConfig() *Config {
+	return &Config{
+		Driver: getenv("MAIL_DRIVER", DriverLog),
+		From:   getenv("MAIL_FROM", "no-reply@unchingspot.local"),
+		Dir:    getenv("MAIL_DIR", "./data/mail"),
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     getenv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		},
+	}
+}
+
+// New は設定に応じたMailerを作成します
+func New(config *Config) (Mailer, error) {
+	switch config.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(config.SMTP, config.From)
+	case DriverFile:
+		return NewFileMailer(config.Dir, config.From)
+	case DriverLog:
+		return NewLogMailer(config.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %q", config.Driver)
+	}
+}
+
+// formatMessage はメールをRFC 5322形式に変換します
+// ヘッダーインジェクションを防ぐため、宛先と件名に改行を含むメールはエラーにします
+func formatMessage(from string, msg *Message, now time.Time) ([]byte, error) {
+	for _, value := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, errors.New("mail header must not contain line breaks")
+		}
+	}
+	if msg.To == "" {
+		return nil, errors.New("mail recipient is empty")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes(), nil
+}
+
+// getenv は環境変数を取得し、未設定の場合はfallbackを返します
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMessage(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("成功: ヘッダーと本文", func(t *testing.T) {
+		data, err := formatMessage("from@example.com", &Message{
+			To:      "to@example.com",
+			Subject: "メールアドレスの確認",
+			Body:    "1行目\n2行目",
+		}, now)
+		require.NoError(t, err)
+
+		text := string(data)
+		assert.Contains(t, text, "From: from@example.com\r\n")
+		assert.Contains(t, text, "To: to@example.com\r\n")
+		assert.Contains(t, text, "Subject: =?UTF-8?q?")
+		assert.Contains(t, text, "Date: Fri, 02 Jan 2026 03:04:05 +0000\r\n")
+		assert.Contains(t, text, "\r\n\r\n1行目\r\n2行目")
+	})
+
+	t.Run("エラー: ヘッダーインジェクション", func(t *testing.T) {
+		_, err := formatMessage("from@example.com", &Message{To: "to@example.com\r\nBcc: evil@example.com", Subject: "x"}, now)
+		assert.Error(t, err)
+
+		_, err = formatMessage("from@example.com", &Message{To: "to@example.com", Subject: "x\nBcc: evil@example.com"}, now)
+		assert.Error(t, err)
+
+		_, err = formatMessage("from@example.com", &Message{Subject: "x"}, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer, err := NewFileMailer(dir, "from@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: "a@example.com", Subject: "first", Body: "body-1"}))
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: "b@example.com", Subject: "second", Body: "body-2"}))
+
+	// ファイル名は送信順に並ぶ
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	first, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(first), "To: a@example.com")
+	assert.Contains(t, string(first), "body-1")
+	second, err := os.ReadFile(files[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(second), "body-2")
+}
+
+func TestNew(t *testing.T) {
+	mailer, err := New(&Config{Driver: DriverLog, From: "from@example.com"})
+	require.NoError(t, err)
+	assert.NoError(t, mailer.Send(context.Background(), &Message{To: "to@example.com", Subject: "log", Body: "body"}))
+
+	_, err = New(&Config{Driver: DriverSMTP, From: "from@example.com"})
+	assert.Error(t, err)
+
+	_, err = New(&Config{Driver: "unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig はSMTPサーバーの設定
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // 空の場合は認証しない
+	Password string
+}
+
+// smtpMailer はSMTPサーバー経由で送信するMailerの実装
+type smtpMailer struct {
+	config SMTPConfig
+	from   string
+}
+
+// NewSMTPMailer はSMTPサーバー経由で送信するMailerを作成します
+// サーバーがSTARTTLSに対応している場合は暗号化して送信します
+func NewSMTPMailer(config SMTPConfig, from string) (Mailer, error) {
+	if config.Host == "" {
+		return nil, errors.New("SMTP host is not set")
+	}
+	if from == "" {
+		return nil, errors.New("mail sender is not set")
+	}
+	return &smtpMailer{config: config, from: from}, nil
+}
+
+// Send はメールを送信します
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	data, err := formatMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	// smtp.SendMailはcontextに対応していないため、キャンセルされた場合は送信しない
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.from, []string{msg.To}, data); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
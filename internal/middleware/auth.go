@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 )
 
@@ -17,10 +18,20 @@ const (
 	UserIDKey contextKey = "user_id"
 	// UserEmailKey はコンテキストに保存されるユーザーメールのキー
 	UserEmailKey contextKey = "user_email"
+	// TokenClaimsKey はコンテキストに保存される検証済みのトークンのクレームのキー
+	TokenClaimsKey contextKey = "token_claims"
 )
 
-// AuthMiddleware はJWTトークンを検証し、ユーザー情報をコンテキストに設定するミドルウェアです
-func AuthMiddleware(next http.Handler) http.Handler {
+// NewAuthMiddleware はJWTトークンを検証し、ユーザー情報をコンテキストに設定するミドルウェアを作成します
+// keySetとrevocationStoreにはservice.NewAuthServiceと同じものを指定してください
+func NewAuthMiddleware(keySet *util.KeySet, revocationStore revocation.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return authHandler(next, keySet, revocationStore)
+	}
+}
+
+// authHandler はトークンを検証してから次のハンドラーを呼び出すハンドラーを返します
+func authHandler(next http.Handler, keySet *util.KeySet, revocationStore revocation.Store) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Authorizationヘッダーを取得
 		authHeader := r.Header.Get("Authorization")
@@ -38,15 +49,33 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		// トークンを検証
-		claims, err := util.ValidateToken(tokenString)
+		claims, err := keySet.ValidateToken(tokenString)
 		if err != nil {
 			respondError(w, http.StatusUnauthorized, "invalid or expired token")
 			return
 		}
 
+		// jtiのないトークンは個別に失効できないため受け付けない
+		if claims.ID == "" {
+			respondError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		// ログアウト済みのトークンでないかを確認
+		revoked, err := revocationStore.IsRevoked(r.Context(), claims.ID, claims.UserID, claims.Generation)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to verify token")
+			return
+		}
+		if revoked {
+			respondError(w, http.StatusUnauthorized, "token has been revoked")
+			return
+		}
+
 		// ユーザー情報をコンテキストに設定
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
 		ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+		ctx = context.WithValue(ctx, TokenClaimsKey, claims)
 
 		// 次のハンドラーを呼び出し
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -65,6 +94,12 @@ func GetUserEmailFromContext(ctx context.Context) (string, bool) {
 	return email, ok
 }
 
+// GetTokenClaimsFromContext はコンテキストから検証済みのトークンのクレームを取得します
+func GetTokenClaimsFromContext(ctx context.Context) (*util.JWTClaims, bool) {
+	claims, ok := ctx.Value(TokenClaimsKey).(*util.JWTClaims)
+	return claims, ok
+}
+
 // respondError はエラーレスポンスを返します
 func respondError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	// MinAreaPins はAreaを構成するPinの最小数
+	MinAreaPins = 3
+	// MaxAreaPins はAreaを構成するPinの最大数
+	MaxAreaPins = 100
+	// MaxAreaNameLength はAreaの名前の最大文字数
+	MaxAreaNameLength = 255
+)
+
+// ErrNotCycle は接続が1つの閉路になっていないエラー
+var ErrNotCycle = errors.New("connects do not form a single cycle")
+
+// Area は順序付きのPinの環で囲まれた範囲（ポリゴン）を表します
+// ポリゴンは作成時点のPinの位置で作成され、その後Pinを移動・削除しても変わりません
+type Area struct {
+	ID               string          `json:"id"`
+	UserID           string          `json:"user_id"`
+	Name             string          `json:"name"`
+	PinIDs           []string        `json:"pin_ids"`            // 環を構成するPinのID（順序通り、始点は繰り返さない）
+	Geometry         json.RawMessage `json:"geometry"`           // GeoJSON Polygon
+	AreaSquareMeters float64         `json:"area_square_meters"` // 測地線で計算した面積（平方メートル）
+	Centroid         LatLng          `json:"centroid"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+// Edge はPinの組を表す無向の辺です
+type Edge struct {
+	From string
+	To   string
+}
+
+// OrderRing は辺の集合が全ての頂点を通る1つの閉路になっている場合、その頂点を順序通りに返します
+// 始点は最初の辺のFromとし、最初の辺の向きに沿って辿ります
+// 辺が3本未満の場合、頂点の次数が2でない場合、閉路が複数に分かれている場合はErrNotCycleを返します
+func OrderRing(edges []Edge) ([]string, error) {
+	if len(edges) < MinAreaPins {
+		return nil, ErrNotCycle
+	}
+
+	adjacent := make(map[string][]string, len(edges))
+	for _, e := range edges {
+		if e.From == e.To {
+			return nil, ErrNotCycle
+		}
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+		adjacent[e.To] = append(adjacent[e.To], e.From)
+	}
+
+	// 閉路では全ての頂点の次数が2で、頂点数と辺の数が等しい
+	if len(adjacent) != len(edges) {
+		return nil, ErrNotCycle
+	}
+	for _, next := range adjacent {
+		if len(next) != 2 || next[0] == next[1] {
+			return nil, ErrNotCycle
+		}
+	}
+
+	ring := make([]string, 0, len(edges))
+	prev, current := edges[0].From, edges[0].To
+	ring = append(ring, prev)
+	for current != edges[0].From {
+		ring = append(ring, current)
+		next := adjacent[current][0]
+		if next == prev {
+			next = adjacent[current][1]
+		}
+		prev, current = current, next
+	}
+
+	// 途中で始点に戻った場合は閉路が複数に分かれている
+	if len(ring) != len(edges) {
+		return nil, ErrNotCycle
+	}
+
+	return ring, nil
+}
+
+// IsSimpleRing は頂点の環が単純な多角形（辺が交差せず、面積が0でない）になるかを判定します
+// 頂点は始点を繰り返さずに指定し、経度・緯度を平面座標として判定します（PostGISのST_IsValidと同じ扱い）
+func IsSimpleRing(points []LatLng) bool {
+	n := len(points)
+	if n < MinAreaPins {
+		return false
+	}
+
+	// 面積が0（全ての頂点が一直線上にある）場合は無効
+	var area float64
+	for i := 0; i < n; i++ {
+		p, q := points[i], points[(i+1)%n]
+		area += p.Longitude*q.Latitude - q.Longitude*p.Latitude
+	}
+	if area == 0 {
+		return false
+	}
+
+	// 同じ座標の頂点があると辺同士が接するため無効
+	seen := make(map[LatLng]bool, n)
+	for _, p := range points {
+		if seen[p] {
+			return false
+		}
+		seen[p] = true
+	}
+
+	// 隣り合わない辺同士が交差していないか確認する
+	for i := 0; i < n; i++ {
+		a1, a2 := points[i], points[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			// 隣り合う辺は頂点を共有するため除外
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			b1, b2 := points[j], points[(j+1)%n]
+			if segmentsIntersect(a1, a2, b1, b2) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// segmentsIntersect は2つの線分が交差または接しているかを判定します
+func segmentsIntersect(p1, p2, q1, q2 LatLng) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	// 一直線上にある場合は線分の範囲が重なるかで判定する
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+// orientation はaからbへの直線に対してcが左側なら正、右側なら負、直線上なら0を返します
+func orientation(a, b, c LatLng) float64 {
+	return (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(c.Longitude-a.Longitude)
+}
+
+// onSegment はaとbを結ぶ線分と同じ直線上にあるcが線分の範囲内にあるかを判定します
+func onSegment(a, b, c LatLng) bool {
+	return min(a.Longitude, b.Longitude) <= c.Longitude && c.Longitude <= max(a.Longitude, b.Longitude) &&
+		min(a.Latitude, b.Latitude) <= c.Latitude && c.Latitude <= max(a.Latitude, b.Latitude)
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRing(t *testing.T) {
+	// 順不同・向きもばらばらな辺から閉路を組み立てる
+	ring, err := OrderRing([]Edge{
+		{From: "a", To: "b"},
+		{From: "c", To: "d"},
+		{From: "c", To: "b"},
+		{From: "a", To: "d"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ring)
+}
+
+func TestOrderRing_Triangle(t *testing.T) {
+	ring, err := OrderRing([]Edge{{From: "x", To: "y"}, {From: "z", To: "x"}, {From: "y", To: "z"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y", "z"}, ring)
+}
+
+func TestOrderRing_NotCycle(t *testing.T) {
+	tests := map[string][]Edge{
+		"辺が少ない": {{From: "a", To: "b"}, {From: "b", To: "a"}},
+		"開いた経路": {{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "d"}},
+		"自己ループ": {{From: "a", To: "a"}, {From: "a", To: "b"}, {From: "b", To: "a"}},
+		"重複した辺": {{From: "a", To: "b"}, {From: "b", To: "a"}, {From: "a", To: "b"}},
+		"分岐がある": {{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}, {From: "a", To: "d"}},
+		"2つの閉路": {
+			{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"},
+			{From: "d", To: "e"}, {From: "e", To: "f"}, {From: "f", To: "d"},
+		},
+	}
+
+	for name, edges := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := OrderRing(edges)
+			assert.ErrorIs(t, err, ErrNotCycle)
+		})
+	}
+}
+
+func TestIsSimpleRing(t *testing.T) {
+	square := []LatLng{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 1, Longitude: 0},
+	}
+	assert.True(t, IsSimpleRing(square))
+
+	// 凹多角形も有効
+	assert.True(t, IsSimpleRing([]LatLng{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 2},
+		{Latitude: 2, Longitude: 2},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 2, Longitude: 0},
+	}))
+
+	// 辺が交差する（蝶ネクタイ型）
+	assert.False(t, IsSimpleRing([]LatLng{square[0], square[2], square[1], square[3]}))
+
+	// 一直線上
+	assert.False(t, IsSimpleRing([]LatLng{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 2, Longitude: 2},
+	}))
+
+	// 同じ座標の頂点
+	assert.False(t, IsSimpleRing([]LatLng{square[0], square[1], square[2], square[0], square[3]}))
+
+	// 頂点が足りない
+	assert.False(t, IsSimpleRing(square[:2]))
+}
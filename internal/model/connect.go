@@ -1,10 +1,67 @@
 package model
 
+import "time"
+
 // Connect は2つのピン間の接続を表します
 type Connect struct {
-	ID     string `db:"id" json:"id"`
-	UserID string `db:"user_id" json:"user_id"`
-	PinID1 string `db:"pins_id_1" json:"pin_id_1"`
-	PinID2 string `db:"pins_id_2" json:"pin_id_2"`
-	Show   bool   `db:"show" json:"show"`
+	ID        string    `db:"id" json:"id"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	PinID1    string    `db:"pins_id_1" json:"pin_id_1"`
+	PinID2    string    `db:"pins_id_2" json:"pin_id_2"`
+	Show      bool      `db:"show" json:"show"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// ConnectLine は両端のPinの座標、距離、方位を含むConnectを表します
+// Connectのレスポンスは全てこの形式で返します
+type ConnectLine struct {
+	*Connect
+	From           LatLng    `json:"from"`
+	To             LatLng    `json:"to"`
+	Geometry       *Geometry `json:"geometry"`        // FromからToへのLineString
+	DistanceMeters float64   `json:"distance_meters"` // 両端のPin間の測地線距離（メートル）
+	Bearing        float64   `json:"bearing"`         // FromからToへの初期方位角（北を0とした時計回りの度数）
+	Pin1           *Pin      `json:"pin_1,omitempty"` // ?expand=pins の場合のみ
+	Pin2           *Pin      `json:"pin_2,omitempty"` // ?expand=pins の場合のみ
+}
+
+// LineString はFromからToへのGeoJSON LineStringを返します
+func (l *ConnectLine) LineString() *Geometry {
+	return &Geometry{
+		Type: GeoJSONTypeLineString,
+		Coordinates: [][]float64{
+			{l.From.Longitude, l.From.Latitude},
+			{l.To.Longitude, l.To.Latitude},
+		},
+	}
+}
+
+// ConnectPath はConnectを辿ったPin間の経路を表します
+type ConnectPath struct {
+	Pins           []*Pin   `json:"pins"`            // 始点から終点までのPin（順序通り）
+	ConnectIDs     []string `json:"connect_ids"`     // 経路を構成するConnect（順序通り）
+	DistanceMeters float64  `json:"distance_meters"` // 経路の合計距離（メートル）
+}
+
+// ConnectGraph はユーザーのPinを頂点、Connectを辺とするネットワークを表します
+type ConnectGraph struct {
+	Nodes        []*ConnectGraphNode `json:"nodes"`
+	Edges        []*ConnectLine      `json:"edges"`
+	Components   [][]string          `json:"components"`    // 連結成分ごとのPin ID
+	IsolatedPins []string            `json:"isolated_pins"` // Connectのない孤立したPinのID
+	Cycles       []*ConnectCycle     `json:"cycles"`        // 基本閉路
+}
+
+// ConnectGraphNode はネットワークの頂点（Pin）を表します
+type ConnectGraphNode struct {
+	*Pin
+	Degree    int `json:"degree"`    // 接続しているConnectの数
+	Component int `json:"component"` // 属する連結成分（ConnectGraph.Componentsのインデックス）
+}
+
+// ConnectCycle はConnectの閉路を表します
+// connect_ids[i]はpin_ids[i]とpin_ids[i+1]を結び、最後のConnectは終点から始点に戻ります
+type ConnectCycle struct {
+	PinIDs     []string `json:"pin_ids"`
+	ConnectIDs []string `json:"connect_ids"`
 }
@@ -0,0 +1,27 @@
+package model
+
+// BoundingBox は緯度経度で表される矩形範囲を表します
+// MinLngがMaxLngより大きい場合は日付変更線（経度180度）をまたぐ範囲として扱います
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// CrossesAntimeridian は範囲が日付変更線をまたぐかどうかを返します
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// Split は日付変更線をまたぐ範囲を東西2つの範囲に分割します
+// またがない場合は自身のみを含むスライスを返します
+func (b BoundingBox) Split() []BoundingBox {
+	if !b.CrossesAntimeridian() {
+		return []BoundingBox{b}
+	}
+	return []BoundingBox{
+		{MinLat: b.MinLat, MinLng: b.MinLng, MaxLat: b.MaxLat, MaxLng: 180},
+		{MinLat: b.MinLat, MinLng: -180, MaxLat: b.MaxLat, MaxLng: b.MaxLng},
+	}
+}
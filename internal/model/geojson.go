@@ -0,0 +1,59 @@
+package model
+
+// GeoJSONのtype値
+const (
+	GeoJSONTypeFeatureCollection = "FeatureCollection"
+	GeoJSONTypeFeature           = "Feature"
+	GeoJSONTypePoint             = "Point"
+	GeoJSONTypeLineString        = "LineString"
+)
+
+// FeatureCollection はRFC 7946のGeoJSON FeatureCollectionを表します
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// Feature はRFC 7946のGeoJSON Featureを表します
+type Feature struct {
+	Type       string      `json:"type"`
+	ID         string      `json:"id,omitempty"`
+	Geometry   *Geometry   `json:"geometry"`
+	Properties interface{} `json:"properties"`
+}
+
+// Geometry はRFC 7946のGeoJSON Geometryを表します
+// 座標は[経度, 緯度]の順序
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// LatLng は緯度経度の組を表します
+type LatLng struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewPinFeature はPinをPoint Featureに変換します
+func NewPinFeature(pin *Pin) *Feature {
+	return &Feature{
+		Type: GeoJSONTypeFeature,
+		ID:   pin.ID,
+		Geometry: &Geometry{
+			Type:        GeoJSONTypePoint,
+			Coordinates: []float64{pin.Longitude, pin.Latitude},
+		},
+		Properties: pin,
+	}
+}
+
+// NewConnectLineFeature はConnectを両端のPinを結ぶLineString Featureに変換します
+func NewConnectLineFeature(line *ConnectLine) *Feature {
+	return &Feature{
+		Type:       GeoJSONTypeFeature,
+		ID:         line.ID,
+		Geometry:   line.LineString(),
+		Properties: line.Connect,
+	}
+}
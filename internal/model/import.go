@@ -0,0 +1,37 @@
+package model
+
+// インポート結果のステータス
+const (
+	ImportStatusCreated = "created"
+	ImportStatusSkipped = "skipped"
+	ImportStatusInvalid = "invalid"
+)
+
+// PinImportRow はファイルから読み取ったインポート対象の1行を表します
+type PinImportRow struct {
+	Index     int
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Err       error // ファイルの読み取り時点で不正と判定された理由
+	Skip      bool  // Errが対象外のジオメトリなど、不正ではなくスキップ扱いとする場合にtrue
+}
+
+// PinImportRowResult はインポートした1行の処理結果を表します
+type PinImportRowResult struct {
+	Index     int     `json:"index"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Status    string  `json:"status"`
+	Reason    string  `json:"reason,omitempty"`
+	PinID     string  `json:"pin_id,omitempty"`
+}
+
+// PinImportReport はPinの一括インポート結果を表します
+type PinImportReport struct {
+	Created int                   `json:"created"`
+	Skipped int                   `json:"skipped"`
+	Invalid int                   `json:"invalid"`
+	Rows    []*PinImportRowResult `json:"rows"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "encoding/json"
+
+// Nullable はJSONでキーが省略された場合とnullが指定された場合を区別する値を表します
+// 部分的な更新のリクエストで、省略された項目を変更せずにnullで値を消去できるようにします
+// 省略を保つため、フィールドにはomitzeroタグを指定してください
+type Nullable[T any] struct {
+	Set   bool // キーが指定された場合にtrue
+	Value *T   // nullが指定された場合はnil
+}
+
+// NewNullable はvalueを指定したNullableを作成します
+// valueがnilの場合はnullを指定したことを表します
+func NewNullable[T any](value *T) Nullable[T] {
+	return Nullable[T]{Set: true, Value: value}
+}
+
+// UnmarshalJSON はJSONの値を読み取り、キーが指定されたことを記録します
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// MarshalJSON は値をJSONに変換します
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Value)
+}
+
+// IsZero はキーが指定されていない場合にtrueを返します
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+
+// applyTo はキーが指定された場合のみdstを値で置き換えます
+func (n Nullable[T]) applyTo(dst **T) {
+	if n.Set {
+		*dst = n.Value
+	}
+}
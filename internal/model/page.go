@@ -0,0 +1,114 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// errMalformedCursor はカーソル文字列の形式が不正なエラー
+var errMalformedCursor = errors.New("malformed cursor")
+
+// PageCursor は (created_at, id) のキーセットページネーションにおける位置を表します
+// クライアントには Encode した不透明な文字列として渡します
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewPageCursor は一覧の最後の要素から次ページのカーソルを作成します
+func NewPageCursor(createdAt time.Time, id string) *PageCursor {
+	return &PageCursor{CreatedAt: createdAt, ID: id}
+}
+
+// Encode はカーソルを不透明な文字列に変換します
+// created_atはマイクロ秒精度のUNIX時刻として保持し、PostgreSQLのTIMESTAMPと同じ精度で比較できるようにします
+func (c *PageCursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParsePageCursor はEncodeで作成された文字列をカーソルに変換します
+// idはデータベースでuuidとして比較するため、UUIDでない場合は不正なカーソルとして扱います
+func ParsePageCursor(s string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errMalformedCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errMalformedCursor
+	}
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	return &PageCursor{CreatedAt: time.UnixMicro(unixMicro).UTC(), ID: id}, nil
+}
+
+// revisionCursorPrefix はリビジョン番号のカーソルを (created_at, id) のカーソルと区別する接頭辞
+const revisionCursorPrefix = "rev:"
+
+// EncodeRevisionCursor は編集履歴の一覧の最後のリビジョン番号から次ページのカーソルを作成します
+// 編集履歴はリビジョン番号の降順で返すため、(created_at, id) ではなくリビジョン番号で位置を表します
+func EncodeRevisionCursor(revision int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(revisionCursorPrefix + strconv.Itoa(revision)))
+}
+
+// ParseRevisionCursor はEncodeRevisionCursorで作成された文字列をリビジョン番号に変換します
+func ParseRevisionCursor(s string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, errMalformedCursor
+	}
+
+	value, ok := strings.CutPrefix(string(raw), revisionCursorPrefix)
+	if !ok {
+		return 0, errMalformedCursor
+	}
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision <= 0 {
+		return 0, errMalformedCursor
+	}
+
+	return revision, nil
+}
+
+// PinPage はPin一覧の1ページを表します
+type PinPage struct {
+	Items      []*Pin `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"` // 次のページが無い場合は省略
+}
+
+// ConnectPage はConnect一覧の1ページを表します
+type ConnectPage struct {
+	Items      []*ConnectLine `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"` // 次のページが無い場合は省略
+}
+
+// ReviewPage はレビュー一覧の1ページを表します
+type ReviewPage struct {
+	Items      []*Review `json:"items"`
+	NextCursor string    `json:"next_cursor,omitempty"` // 次のページが無い場合は省略
+}
+
+// PinRevisionPage はPinの編集履歴の1ページを表します
+type PinRevisionPage struct {
+	Items      []*PinRevision `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"` // 次のページが無い場合は省略
+}
+
+// AreaPage はArea一覧の1ページを表します
+type AreaPage struct {
+	Items      []*Area `json:"items"`
+	NextCursor string  `json:"next_cursor,omitempty"` // 次のページが無い場合は省略
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	cursor := NewPageCursor(createdAt, "0b6f5d7e-0000-4000-8000-000000000001")
+
+	parsed, err := ParsePageCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(parsed.CreatedAt))
+	assert.Equal(t, cursor.ID, parsed.ID)
+}
+
+func TestPageCursor_TruncatesToMicroseconds(t *testing.T) {
+	// PostgreSQLのTIMESTAMPはマイクロ秒精度のため、それ以下は切り捨てる
+	createdAt := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+
+	parsed, err := ParsePageCursor(NewPageCursor(createdAt, "0b6f5d7e-0000-4000-8000-000000000001").Encode())
+	require.NoError(t, err)
+	assert.Equal(t, 123456000, parsed.CreatedAt.Nanosecond())
+}
+
+func TestRevisionCursor_RoundTrip(t *testing.T) {
+	revision, err := ParseRevisionCursor(EncodeRevisionCursor(42))
+	require.NoError(t, err)
+	assert.Equal(t, 42, revision)
+
+	// (created_at, id) のカーソルや0以下の番号は受け付けない
+	for _, s := range []string{"invalid!", NewPageCursor(time.Now(), "id").Encode(), EncodeRevisionCursor(0)} {
+		_, err := ParseRevisionCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParsePageCursor_Invalid(t *testing.T) {
+	// idがUUIDでないカーソルも受け付けない
+	for _, s := range []string{"invalid!", "bm8tY29sb24", "YWJjOmlk", "MTIzOg", "MTp4"} {
+		_, err := ParsePageCursor(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// PasswordResetToken はパスワードを再設定するための1回限りのトークンを表します
+// トークン自体は保存せず、SHA-256ハッシュのみを保存します
+type PasswordResetToken struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"` // 使用済みになった日時
+	CreatedAt time.Time  `db:"created_at"`
+}
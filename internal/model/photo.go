@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// MaxPhotosPerPin は1つのPinに添付できる写真の最大枚数
+const MaxPhotosPerPin = 20
+
+// Photo はPinに添付された写真を表します
+// 画像本体とサムネイルはPhotoStorageに保存され、URLから取得できます
+type Photo struct {
+	ID           string     `db:"id" json:"id"`
+	PinID        string     `db:"pin_id" json:"pin_id"`
+	UserID       string     `db:"user_id" json:"user_id"`
+	StorageKey   string     `db:"storage_key" json:"-"`
+	ThumbnailKey string     `db:"thumbnail_key" json:"-"`
+	ContentType  string     `db:"content_type" json:"content_type"`
+	Width        int        `db:"width" json:"width"`
+	Height       int        `db:"height" json:"height"`
+	SizeBytes    int64      `db:"size_bytes" json:"size_bytes"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	URL          string     `db:"-" json:"url"`
+	ThumbnailURL string     `db:"-" json:"thumbnail_url"`
+}
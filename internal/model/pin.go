@@ -2,14 +2,107 @@ package model
 
 import "time"
 
+// Pinの公開範囲
+const (
+	// PinVisibilityPrivate は所有者のみが閲覧できる公開範囲
+	PinVisibilityPrivate = "private"
+	// PinVisibilitySharedByLink は共有リンクを知っている人が閲覧できる公開範囲
+	PinVisibilitySharedByLink = "shared-by-link"
+	// PinVisibilityPublic は誰でも閲覧できる公開範囲
+	PinVisibilityPublic = "public"
+)
+
+// IsValidPinVisibility は公開範囲の値が有効かどうかを判定します
+func IsValidPinVisibility(visibility string) bool {
+	switch visibility {
+	case PinVisibilityPrivate, PinVisibilitySharedByLink, PinVisibilityPublic:
+		return true
+	}
+	return false
+}
+
 // Pin はトイレの位置マーカーを表します
 type Pin struct {
-	ID        string     `db:"id" json:"id"`
-	Name      string     `db:"name" json:"name"`
-	UserID    string     `db:"user_id" json:"user_id"`
-	Latitude  float64    `json:"latitude"`  // PostGISから抽出
-	Longitude float64    `json:"longitude"` // PostGISから抽出
-	CreatedAt time.Time  `db:"created_at" json:"created_at"`
-	EditedAt  time.Time  `db:"edit_at" json:"edited_at"`
-	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	ID         string     `db:"id" json:"id"`
+	Name       string     `db:"name" json:"name"`
+	UserID     string     `db:"user_id" json:"user_id"`
+	Latitude   float64    `json:"latitude"`  // PostGISから抽出
+	Longitude  float64    `json:"longitude"` // PostGISから抽出
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	EditedAt   time.Time  `db:"edit_at" json:"edited_at"`
+	DeletedAt  *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	Visibility string     `db:"visibility" json:"visibility"`
+	ShareToken *string    `db:"share_token" json:"share_token,omitempty"` // 所有者にのみ返す共有リンク用トークン
+	PinAttributes
+	Ratings PinRatings `json:"ratings"` // レビューの集計
+}
+
+// PinAttributes はトイレの設備属性を表します
+// 真偽値の属性がnilの場合は「不明」を表します
+type PinAttributes struct {
+	WheelchairAccessible *bool   `db:"wheelchair_accessible" json:"wheelchair_accessible"`
+	BabyChanging         *bool   `db:"baby_changing" json:"baby_changing"`
+	GenderNeutral        *bool   `db:"gender_neutral" json:"gender_neutral"`
+	Washlet              *bool   `db:"washlet" json:"washlet"`
+	Paid                 *bool   `db:"paid" json:"paid"`
+	FeeAmount            *int    `db:"fee_amount" json:"fee_amount"`       // 利用料金（円）、有料の場合のみ
+	OpeningHours         *string `db:"opening_hours" json:"opening_hours"` // OpenStreetMapのopening_hours形式
+	Timezone             *string `db:"timezone" json:"timezone"`           // 営業時間を評価するタイムゾーン（IANA名）、営業時間を設定する場合は必須
+}
+
+// PinAttributesUpdate はPin更新時の設備属性の変更を表します
+// 省略された属性は変更せず、nullが指定された属性は「不明」に戻します
+type PinAttributesUpdate struct {
+	WheelchairAccessible Nullable[bool]   `json:"wheelchair_accessible,omitzero"`
+	BabyChanging         Nullable[bool]   `json:"baby_changing,omitzero"`
+	GenderNeutral        Nullable[bool]   `json:"gender_neutral,omitzero"`
+	Washlet              Nullable[bool]   `json:"washlet,omitzero"`
+	Paid                 Nullable[bool]   `json:"paid,omitzero"`
+	FeeAmount            Nullable[int]    `json:"fee_amount,omitzero"`
+	OpeningHours         Nullable[string] `json:"opening_hours,omitzero"`
+	Timezone             Nullable[string] `json:"timezone,omitzero"`
+}
+
+// Apply は現在の設備属性に変更を適用した設備属性を返します
+func (u PinAttributesUpdate) Apply(attrs PinAttributes) PinAttributes {
+	u.WheelchairAccessible.applyTo(&attrs.WheelchairAccessible)
+	u.BabyChanging.applyTo(&attrs.BabyChanging)
+	u.GenderNeutral.applyTo(&attrs.GenderNeutral)
+	u.Washlet.applyTo(&attrs.Washlet)
+	u.Paid.applyTo(&attrs.Paid)
+	u.FeeAmount.applyTo(&attrs.FeeAmount)
+	u.OpeningHours.applyTo(&attrs.OpeningHours)
+	u.Timezone.applyTo(&attrs.Timezone)
+	return attrs
+}
+
+// PinFilter はPin一覧の設備属性による絞り込み条件を表します
+// nilの条件は絞り込みに使用しません
+type PinFilter struct {
+	WheelchairAccessible *bool
+	BabyChanging         *bool
+	GenderNeutral        *bool
+	Washlet              *bool
+	Paid                 *bool
+	OpenAt               *time.Time // 指定時刻に営業中のPinのみに絞り込む（営業時間が不明なPinは含まない）
+}
+
+// NearbyPin は検索地点からの距離を含むPinを表します
+type NearbyPin struct {
+	Pin
+	DistanceMeters float64 `json:"distance_m"` // 検索地点からの測地線距離（メートル）
+}
+
+// PinsInBounds はバウンディングボックス検索の結果を表します
+type PinsInBounds struct {
+	Pins      []*Pin `json:"pins"`
+	Truncated bool   `json:"truncated"` // 最大件数を超えたため結果が切り詰められた場合にtrue
+}
+
+// PinCluster は地図の縮小表示用にグリッド単位で集約されたPinを表します
+type PinCluster struct {
+	Latitude  float64 `json:"latitude"`  // クラスタの重心
+	Longitude float64 `json:"longitude"` // クラスタの重心
+	Count     int     `json:"count"`
+	Pin       *Pin    `json:"pin,omitempty"` // Countが1の場合のみ実際のPinを含む
 }
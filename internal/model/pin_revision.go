@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// PinRevision はPinの1回の更新で変更された名前と位置を表します
+// Revisionは同じPinの中で1から順に採番されます
+type PinRevision struct {
+	ID           string    `db:"id" json:"id"`
+	PinID        string    `db:"pin_id" json:"pin_id"`
+	Revision     int       `db:"revision" json:"revision"`
+	EditorID     string    `db:"editor_id" json:"editor_id"`
+	OldName      string    `db:"old_name" json:"old_name"`
+	OldLatitude  float64   `db:"old_latitude" json:"old_latitude"`
+	OldLongitude float64   `db:"old_longitude" json:"old_longitude"`
+	NewName      string    `db:"new_name" json:"new_name"`
+	NewLatitude  float64   `db:"new_latitude" json:"new_latitude"`
+	NewLongitude float64   `db:"new_longitude" json:"new_longitude"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
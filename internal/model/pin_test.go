@@ -0,0 +1,53 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinAttributesUpdate_Apply(t *testing.T) {
+	yes := true
+	fee := 100
+	hours := "Mo-Fr 08:00-20:00"
+	timezone := "Asia/Tokyo"
+	current := PinAttributes{
+		WheelchairAccessible: &yes,
+		Washlet:              &yes,
+		Paid:                 &yes,
+		FeeAmount:            &fee,
+		OpeningHours:         &hours,
+		Timezone:             &timezone,
+	}
+
+	// 省略した属性は変更せず、nullを指定した属性は不明に戻す
+	var update PinAttributesUpdate
+	require.NoError(t, json.Unmarshal([]byte(`{"baby_changing": false, "washlet": null}`), &update))
+	attrs := update.Apply(current)
+
+	require.NotNil(t, attrs.BabyChanging)
+	assert.False(t, *attrs.BabyChanging)
+	assert.Nil(t, attrs.Washlet)
+	assert.Equal(t, current.WheelchairAccessible, attrs.WheelchairAccessible)
+	assert.Equal(t, current.FeeAmount, attrs.FeeAmount)
+	assert.Equal(t, current.OpeningHours, attrs.OpeningHours)
+	assert.Equal(t, current.Timezone, attrs.Timezone)
+
+	// 変更前の設備属性は変更しない
+	assert.NotNil(t, current.Washlet)
+}
+
+func TestPinAttributesUpdate_MarshalJSON(t *testing.T) {
+	no := false
+	update := PinAttributesUpdate{
+		BabyChanging: NewNullable(&no),
+		Washlet:      NewNullable[bool](nil),
+	}
+
+	// 指定していない属性は省略される
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"baby_changing": false, "washlet": null}`, string(data))
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// RefreshToken はアクセストークンを再発行するためのリフレッシュトークンを表します
+// トークン自体は保存せず、SHA-256ハッシュのみを保存します
+type RefreshToken struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	FamilyID  string     `db:"family_id"` // 同じログインから交換で発行されたトークンの系列
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RotatedAt *time.Time `db:"rotated_at"` // 新しいトークンに交換された日時
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
@@ -13,18 +13,62 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// RefreshTokenRequest はトークン再発行リクエストを表します
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// VerifyEmailRequest はメールアドレス確認リクエストを表します
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ResendVerificationRequest はメールアドレス確認用トークンの再送信リクエストを表します
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPasswordRequest はパスワード再設定用トークンの送信リクエストを表します
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest はパスワード再設定リクエストを表します
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// LogoutRequest はログアウトリクエストを表します
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // 任意: 指定すると同じログインのリフレッシュトークンも失効させる
+}
+
 // CreatePinRequest はピン作成リクエストを表します
 type CreatePinRequest struct {
 	Name      string  `json:"name" validate:"required"`
 	Latitude  float64 `json:"latitude" validate:"required,min=-90,max=90"`
 	Longitude float64 `json:"longitude" validate:"required,min=-180,max=180"`
+	PinAttributes
 }
 
 // UpdatePinRequest はピン更新リクエストを表します
+// 省略した設備属性は変更しません
 type UpdatePinRequest struct {
 	Name      string  `json:"name"`
 	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
 	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+	PinAttributesUpdate
+}
+
+// UpdatePinVisibilityRequest はピンの公開範囲更新リクエストを表します
+type UpdatePinVisibilityRequest struct {
+	Visibility string `json:"visibility" validate:"required,oneof=private shared-by-link public"`
+}
+
+// ReviewRequest はレビューの作成・更新リクエストを表します
+type ReviewRequest struct {
+	ReviewScores
 }
 
 // CreateConnectRequest は接続作成リクエストを表します
@@ -40,3 +84,11 @@ type UpdateConnectRequest struct {
 	PinID2 string `json:"pin_id_2" validate:"uuid"`
 	Show   *bool  `json:"show"`
 }
+
+// CreateAreaRequest はArea作成リクエストを表します
+// pin_idsとconnect_idsのどちらか一方を指定します
+type CreateAreaRequest struct {
+	Name       string   `json:"name" validate:"required"`
+	PinIDs     []string `json:"pin_ids"`     // 環を構成するPinのIDを順序通りに指定
+	ConnectIDs []string `json:"connect_ids"` // 1つの閉路になるConnectのIDを順不同で指定
+}
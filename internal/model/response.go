@@ -2,8 +2,10 @@ package model
 
 // AuthResponse はトークンとユーザー情報を含む認証レスポンスを表します
 type AuthResponse struct {
-	Token string `json:"token"`
-	User  *User  `json:"user"`
+	Token        string `json:"token"`         // アクセストークン（JWT）
+	RefreshToken string `json:"refresh_token"` // アクセストークンの再発行に使用するトークン（1回のみ使用可能）
+	ExpiresIn    int    `json:"expires_in"`    // アクセストークンの有効期間（秒）
+	User         *User  `json:"user"`
 }
 
 // ErrorResponse はエラーレスポンスを表します
@@ -27,4 +29,15 @@ const (
 	ErrCodeConflict       = "CONFLICT"
 	ErrCodeInternalServer = "INTERNAL_SERVER_ERROR"
 	ErrCodeDatabaseError  = "DATABASE_ERROR"
+
+	// Connectの整合性エラー
+	ErrCodeSelfConnect      = "SELF_CONNECT"
+	ErrCodeDuplicateConnect = "DUPLICATE_CONNECT"
+	ErrCodePinNotOwned      = "PIN_NOT_OWNED"
+
+	// Connectの経路探索エラー
+	ErrCodeNoPath = "NO_PATH"
+
+	// メールアドレスが未確認のユーザーには許可されていない操作のエラー
+	ErrCodeEmailNotVerified = "EMAIL_NOT_VERIFIED"
 )
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+const (
+	// MinReviewScore はレビューのスコアの最小値
+	MinReviewScore = 1
+	// MaxReviewScore はレビューのスコアの最大値
+	MaxReviewScore = 5
+	// MaxReviewCommentLength はレビューのコメントの最大文字数
+	MaxReviewCommentLength = 500
+)
+
+// Review はPinに対するユーザーの評価とレビューを表します
+type Review struct {
+	ID        string     `db:"id" json:"id"`
+	PinID     string     `db:"pin_id" json:"pin_id"`
+	UserID    string     `db:"user_id" json:"user_id"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	EditedAt  time.Time  `db:"edit_at" json:"edited_at"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	ReviewScores
+}
+
+// ReviewScores はレビューの評価項目を表します
+// 各スコアは1〜5の5段階評価です
+type ReviewScores struct {
+	Cleanliness       int    `db:"cleanliness" json:"cleanliness"`               // 清潔さ（5が最も清潔）
+	Crowding          int    `db:"crowding" json:"crowding"`                     // 混雑具合（5が最も空いている）
+	PaperAvailability int    `db:"paper_availability" json:"paper_availability"` // トイレットペーパーの有無（5が常に十分）
+	Comment           string `db:"comment" json:"comment"`                       // 短いレビュー本文（任意）
+}
+
+// PinRatings はPinに対するレビューの集計を表します
+type PinRatings struct {
+	Count             int          `json:"count"`
+	Cleanliness       ScoreSummary `json:"cleanliness"`
+	Crowding          ScoreSummary `json:"crowding"`
+	PaperAvailability ScoreSummary `json:"paper_availability"`
+}
+
+// ScoreSummary は1つの評価項目の集計を表します
+type ScoreSummary struct {
+	Mean      *float64 `json:"mean"`      // 平均値、レビューが無い場合はnull
+	Histogram [5]int   `json:"histogram"` // スコア1〜5それぞれの件数
+}
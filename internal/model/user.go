@@ -11,4 +11,14 @@ type User struct {
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
 	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// EmailVerifiedAt はメールアドレスの確認が完了した日時（未確認の場合はnull）
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"email_verified_at"`
+	// VerificationMailFailedAt は確認メールの送信に最後に失敗した日時（失敗していない場合はnull）
+	// nullでない場合は確認メールの再送信が必要です
+	VerificationMailFailedAt *time.Time `db:"verification_mail_failed_at" json:"verification_mail_failed_at,omitempty"`
+}
+
+// IsEmailVerified はメールアドレスの確認が完了しているかを返します
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
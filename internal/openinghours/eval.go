@@ -0,0 +1,53 @@
+package openinghours
+
+import "time"
+
+// IsOpen は指定時刻に営業中かどうかを判定します
+// 時刻はtのタイムゾーン（Pinの現地時間）で評価します
+// 祝日（PH）はカレンダーを持たないため判定に使用しません
+func (s *Schedule) IsOpen(t time.Time) bool {
+	day := mondayIndex(t.Weekday())
+	minute := t.Hour()*60 + t.Minute()
+
+	// 当日の時間帯
+	if r := s.ruleFor(day); r != nil && r.contains(minute) {
+		return true
+	}
+
+	// 前日から日付をまたいで続いている時間帯（例: 22:00-02:00）
+	previous := (day + 6) % 7
+	if r := s.ruleFor(previous); r != nil && r.contains(minute+minutesPerDay) {
+		return true
+	}
+
+	return false
+}
+
+// ruleFor は指定した曜日に適用されるルールを返します
+// 同じ曜日を対象とするルールが複数ある場合は最後のルールが優先されます
+func (s *Schedule) ruleFor(day int) *rule {
+	for i := len(s.rules) - 1; i >= 0; i-- {
+		if s.rules[i].days[day] {
+			return &s.rules[i]
+		}
+	}
+	return nil
+}
+
+// contains はルールの営業時間帯に指定した分が含まれるかを判定します
+func (r *rule) contains(minute int) bool {
+	if r.closed {
+		return false
+	}
+	for _, sp := range r.spans {
+		if minute >= sp.start && minute < sp.end {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayIndex はtime.Weekdayを月曜始まりのインデックスに変換します
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
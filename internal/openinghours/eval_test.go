@@ -0,0 +1,58 @@
+package openinghours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_IsOpen(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	// 2024-01-01は月曜日
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, jst)
+	}
+
+	tests := []struct {
+		spec string
+		at   time.Time
+		want bool
+	}{
+		{"24/7", at(1, 3, 0), true},
+		{"Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00", at(1, 8, 0), true},
+		{"Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00", at(1, 20, 0), false},
+		{"Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00", at(6, 9, 0), false},
+		{"Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00", at(7, 17, 59), true},
+		{"Mo-Sa 07:00-12:00,13:00-22:00; Su off", at(2, 12, 30), false},
+		{"Mo-Sa 07:00-12:00,13:00-22:00; Su off", at(2, 13, 0), true},
+		{"Mo-Sa 07:00-12:00,13:00-22:00; Su off", at(7, 13, 0), false},
+		// 日付をまたぐ時間帯は翌日の未明も営業中
+		{"Fr 22:00-02:00", at(5, 23, 0), true},
+		{"Fr 22:00-02:00", at(6, 1, 59), true},
+		{"Fr 22:00-02:00", at(6, 2, 0), false},
+		{"Fr 22:00-02:00", at(4, 1, 0), false},
+		// 後のルールが前のルールを上書きする
+		{"Mo-Su 08:00-20:00; We off", at(3, 12, 0), false},
+		{"Mo-Su 08:00-20:00; We off", at(4, 12, 0), true},
+		// 祝日のみのルールは曜日の判定に影響しない
+		{"Mo-Su 00:00-24:00; PH off", at(1, 12, 0), true},
+	}
+
+	for _, tt := range tests {
+		schedule, err := Parse(tt.spec)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, schedule.IsOpen(tt.at), "%s at %s", tt.spec, tt.at)
+	}
+}
+
+func TestSchedule_IsOpen_UsesTimeZoneOfTime(t *testing.T) {
+	schedule, err := Parse("Mo-Fr 08:00-20:00")
+	require.NoError(t, err)
+
+	// UTCの月曜23:00は日本時間の火曜08:00
+	at := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	assert.False(t, schedule.IsOpen(at))
+	assert.True(t, schedule.IsOpen(at.In(time.FixedZone("JST", 9*60*60))))
+}
@@ -0,0 +1,248 @@
+// Package openinghours はOpenStreetMapのopening_hours形式の営業時間を扱います
+//
+// 対応しているのは次のような一般的な記法のサブセットです
+//
+//	24/7
+//	Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00
+//	Mo-Sa 07:00-12:00,13:00-22:00; Su off
+//	22:00-02:00
+//	PH off
+//
+// 月・日付・日の出/日の入りなどのセレクタには対応していません
+package openinghours
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSyntax は営業時間の記法が不正または未対応であることを表すエラー
+var ErrInvalidSyntax = errors.New("invalid opening_hours syntax")
+
+const (
+	minutesPerDay = 24 * 60
+	// maxExtendedMinutes は翌日にまたがる時間帯の終了時刻の上限（48:00）
+	maxExtendedMinutes = 2 * minutesPerDay
+)
+
+// weekdayNames はOSMの曜日表記（月曜始まり）
+var weekdayNames = []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+
+// Schedule は解析済みの営業時間を表します
+type Schedule struct {
+	rules []rule
+}
+
+// rule はセミコロンで区切られた1つのルールを表します
+// 後に書かれたルールは、対象の曜日について前のルールを上書きします
+type rule struct {
+	days     [7]bool // 月曜始まりの曜日ごとの対象フラグ
+	holidays bool    // PH（祝日）セレクタを含む
+	closed   bool    // off/closed
+	spans    []span
+}
+
+// span は1日の中の営業時間帯（0時からの分）を表します
+// 翌日にまたがる場合、endは1440を超えます
+type span struct {
+	start int
+	end   int
+}
+
+// Parse はopening_hours形式の文字列を解析します
+func Parse(s string) (*Schedule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty value", ErrInvalidSyntax)
+	}
+
+	parts := strings.Split(s, ";")
+	schedule := &Schedule{rules: make([]rule, 0, len(parts))}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			// 末尾のセミコロンのみ許可する
+			if i == len(parts)-1 && i > 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%w: empty rule", ErrInvalidSyntax)
+		}
+
+		r, err := parseRule(part)
+		if err != nil {
+			return nil, err
+		}
+		schedule.rules = append(schedule.rules, r)
+	}
+
+	return schedule, nil
+}
+
+// parseRule は "[曜日セレクタ] [時間帯|off|closed|open]" 形式の1ルールを解析します
+func parseRule(s string) (rule, error) {
+	var r rule
+
+	if s == "24/7" {
+		r.days = allDays()
+		r.spans = []span{{start: 0, end: minutesPerDay}}
+		return r, nil
+	}
+
+	// "08:00-12:00, 13:00-18:00" のようにカンマの後に空白がある記法を正規化
+	s = strings.ReplaceAll(s, ", ", ",")
+	fields := strings.Fields(s)
+
+	if len(fields) > 0 && isWeekdaySelector(fields[0]) {
+		days, holidays, err := parseWeekdays(fields[0])
+		if err != nil {
+			return rule{}, err
+		}
+		r.days = days
+		r.holidays = holidays
+		fields = fields[1:]
+	} else {
+		r.days = allDays()
+	}
+
+	switch {
+	case len(fields) == 0:
+		// 曜日のみの場合は終日営業
+		r.spans = []span{{start: 0, end: minutesPerDay}}
+	case len(fields) == 1 && (fields[0] == "off" || fields[0] == "closed"):
+		r.closed = true
+	case len(fields) == 1 && fields[0] == "open":
+		r.spans = []span{{start: 0, end: minutesPerDay}}
+	case len(fields) == 1:
+		spans, err := parseSpans(fields[0])
+		if err != nil {
+			return rule{}, err
+		}
+		r.spans = spans
+	default:
+		return rule{}, fmt.Errorf("%w: unsupported rule %q", ErrInvalidSyntax, s)
+	}
+
+	return r, nil
+}
+
+// isWeekdaySelector はトークンが曜日セレクタで始まるかを判定します
+func isWeekdaySelector(token string) bool {
+	if strings.HasPrefix(token, "PH") {
+		return true
+	}
+	for _, name := range weekdayNames {
+		if strings.HasPrefix(token, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWeekdays は "Mo-Fr,Su" や "PH" 形式の曜日セレクタを解析します
+func parseWeekdays(s string) ([7]bool, bool, error) {
+	var days [7]bool
+	holidays := false
+
+	for _, item := range strings.Split(s, ",") {
+		if item == "PH" {
+			holidays = true
+			continue
+		}
+
+		from, to, isRange := strings.Cut(item, "-")
+		start := weekdayIndex(from)
+		if start < 0 {
+			return days, false, fmt.Errorf("%w: unknown weekday %q", ErrInvalidSyntax, from)
+		}
+		end := start
+		if isRange {
+			end = weekdayIndex(to)
+			if end < 0 {
+				return days, false, fmt.Errorf("%w: unknown weekday %q", ErrInvalidSyntax, to)
+			}
+		}
+
+		// "Sa-Mo" のように週をまたぐ範囲にも対応
+		for i := start; ; i = (i + 1) % 7 {
+			days[i] = true
+			if i == end {
+				break
+			}
+		}
+	}
+
+	return days, holidays, nil
+}
+
+// weekdayIndex は曜日表記を月曜始まりのインデックスに変換します
+func weekdayIndex(name string) int {
+	for i, n := range weekdayNames {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseSpans は "08:00-12:00,13:00-18:00" 形式の時間帯の一覧を解析します
+func parseSpans(s string) ([]span, error) {
+	items := strings.Split(s, ",")
+	spans := make([]span, 0, len(items))
+
+	for _, item := range items {
+		from, to, ok := strings.Cut(item, "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: time range %q must be HH:MM-HH:MM", ErrInvalidSyntax, item)
+		}
+		start, err := parseTime(from)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTime(to)
+		if err != nil {
+			return nil, err
+		}
+
+		if start >= minutesPerDay {
+			return nil, fmt.Errorf("%w: start time %q must be before 24:00", ErrInvalidSyntax, from)
+		}
+		// 終了時刻が開始時刻以前の場合は翌日にまたがる時間帯（例: 22:00-02:00）
+		if end <= start {
+			end += minutesPerDay
+		}
+		if end > maxExtendedMinutes {
+			return nil, fmt.Errorf("%w: time range %q is too long", ErrInvalidSyntax, item)
+		}
+
+		spans = append(spans, span{start: start, end: end})
+	}
+
+	return spans, nil
+}
+
+// parseTime は "HH:MM" 形式の時刻を0時からの分に変換します
+// 翌日にまたがる時間帯の記法のため、48:00までを受け付けます
+func parseTime(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidSyntax, s)
+	}
+	hours, errH := strconv.Atoi(hh)
+	minutes, errM := strconv.Atoi(mm)
+	if errH != nil || errM != nil || hours < 0 || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidSyntax, s)
+	}
+
+	total := hours*60 + minutes
+	if total > maxExtendedMinutes {
+		return 0, fmt.Errorf("%w: time %q is out of range", ErrInvalidSyntax, s)
+	}
+	return total, nil
+}
+
+// allDays は全ての曜日を対象とするフラグを返します
+func allDays() [7]bool {
+	return [7]bool{true, true, true, true, true, true, true}
+}
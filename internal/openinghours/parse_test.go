@@ -0,0 +1,61 @@
+package openinghours
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Valid(t *testing.T) {
+	for _, s := range []string{
+		"24/7",
+		"Mo-Fr 08:00-20:00",
+		"Mo-Fr 08:00-20:00; Sa,Su 10:00-18:00",
+		"Mo-Sa 07:00-12:00,13:00-22:00; Su off",
+		"Mo-Fr 08:00-12:00, 13:00-18:00",
+		"22:00-02:00",
+		"Fr-Mo 18:00-26:00",
+		"Mo-Su 00:00-24:00; PH off",
+		"Sa closed",
+		"Su open",
+		"Mo-Fr",
+		"Mo-Fr 08:00-20:00;",
+	} {
+		_, err := Parse(s)
+		assert.NoError(t, err, s)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"always",
+		"Mo-Xx 08:00-20:00",
+		"Mo-Fr 8:00-20:00",
+		"Mo-Fr 08:00",
+		"Mo-Fr 08:60-20:00",
+		"Mo-Fr 24:00-26:00",
+		"Mo-Fr 20:00-50:00",
+		"Jan-Mar Mo-Fr 08:00-20:00",
+		"Mo-Fr sunrise-sunset",
+		"; Mo 08:00-20:00",
+	} {
+		_, err := Parse(s)
+		assert.ErrorIs(t, err, ErrInvalidSyntax, s)
+	}
+}
+
+func TestParse_Rules(t *testing.T) {
+	schedule, err := Parse("Sa-Mo 22:00-02:00; PH off")
+	require.NoError(t, err)
+	require.Len(t, schedule.rules, 2)
+
+	weekend := schedule.rules[0]
+	assert.Equal(t, [7]bool{true, false, false, false, false, true, true}, weekend.days)
+	assert.Equal(t, []span{{start: 22 * 60, end: 26 * 60}}, weekend.spans)
+
+	holiday := schedule.rules[1]
+	assert.True(t, holiday.holidays)
+	assert.True(t, holiday.closed)
+}
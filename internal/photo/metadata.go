@@ -0,0 +1,227 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// errMalformed は画像の構造が壊れているエラー
+	errMalformed = errors.New("malformed image")
+
+	// exifHeader はEXIFを格納するAPP1セグメントの識別子
+	exifHeader = []byte("Exif\x00\x00")
+	// pngSignature はPNGファイルの先頭8バイト
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+)
+
+const (
+	// tagOrientation は画像の向きを表すEXIFタグ
+	tagOrientation = 0x0112
+	// tagGPSInfo はGPS情報のIFDへのオフセットを表すEXIFタグ
+	tagGPSInfo = 0x8825
+)
+
+// stripJPEG はJPEGから位置情報を取り除き、EXIFのOrientationの値を返します
+// EXIFはGPS情報のIFDのみを消去して残し（向きなどを保つため）、XMPとIPTC（APP13）は位置情報を含み得るため取り除きます
+// EXIFの構造を解釈できない場合は安全側に倒してEXIF全体を取り除きます
+func stripJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, errMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	orientation := 1
+
+	pos := 2
+	for {
+		if pos+2 > len(data) || data[pos] != 0xFF {
+			return nil, 0, errMalformed
+		}
+		marker := data[pos+1]
+
+		// 埋め草の0xFF
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+
+		// 長さを持たないマーカー
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+
+		// EOIまたはSOS以降は画像データなのでそのままコピー
+		if marker == 0xD9 || marker == 0xDA {
+			out = append(out, data[pos:]...)
+			return out, orientation, nil
+		}
+
+		if pos+4 > len(data) {
+			return nil, 0, errMalformed
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, 0, errMalformed
+		}
+		segment := data[pos:end]
+		payload := segment[4:]
+
+		switch {
+		case marker == 0xE1 && bytes.HasPrefix(payload, exifHeader):
+			cleaned := append([]byte(nil), segment...)
+			if o, ok := scrubEXIF(cleaned[4+len(exifHeader):]); ok {
+				orientation = o
+				out = append(out, cleaned...)
+			}
+		case marker == 0xE1, marker == 0xED:
+			// XMP、IPTCなどは取り除く
+		default:
+			out = append(out, segment...)
+		}
+
+		pos = end
+	}
+}
+
+// scrubEXIF はTIFF形式のEXIFデータからGPS情報のIFDを消去し、Orientationの値を返します
+// dataはその場で書き換えられます。構造が不正な場合はokにfalseを返します
+func scrubEXIF(data []byte) (orientation int, ok bool) {
+	if len(data) < 8 {
+		return 0, false
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return 0, false
+	}
+
+	ifd0 := int(order.Uint32(data[4:8]))
+	entries, ok := ifdEntries(data, order, ifd0)
+	if !ok {
+		return 0, false
+	}
+
+	orientation = 1
+	for _, entry := range entries {
+		tag := order.Uint16(data[entry : entry+2])
+		switch tag {
+		case tagOrientation:
+			if v := int(order.Uint16(data[entry+8 : entry+10])); v >= 1 && v <= 8 {
+				orientation = v
+			}
+		case tagGPSInfo:
+			if !clearIFD(data, order, int(order.Uint32(data[entry+8:entry+12]))) {
+				return 0, false
+			}
+		}
+	}
+
+	return orientation, true
+}
+
+// ifdEntries はIFDの各エントリの先頭位置を返します
+func ifdEntries(data []byte, order binary.ByteOrder, offset int) ([]int, bool) {
+	if offset < 8 || offset+2 > len(data) {
+		return nil, false
+	}
+	count := int(order.Uint16(data[offset : offset+2]))
+	if offset+2+count*12 > len(data) {
+		return nil, false
+	}
+
+	entries := make([]int, count)
+	for i := range entries {
+		entries[i] = offset + 2 + i*12
+	}
+	return entries, true
+}
+
+// clearIFD はIFDの全エントリとエントリが参照する値をゼロで埋め、エントリ数を0にします
+func clearIFD(data []byte, order binary.ByteOrder, offset int) bool {
+	entries, ok := ifdEntries(data, order, offset)
+	if !ok {
+		return false
+	}
+
+	for _, entry := range entries {
+		size := typeSize(order.Uint16(data[entry+2:entry+4])) * int(order.Uint32(data[entry+4:entry+8]))
+		if size < 0 {
+			return false
+		}
+		// 4バイトを超える値はエントリの外に格納されている
+		if size > 4 {
+			valueOffset := int(order.Uint32(data[entry+8 : entry+12]))
+			if valueOffset < 8 || valueOffset+size > len(data) {
+				return false
+			}
+			clear(data[valueOffset : valueOffset+size])
+		}
+		clear(data[entry : entry+12])
+	}
+	order.PutUint16(data[offset:offset+2], 0)
+
+	return true
+}
+
+// typeSize はTIFFのデータ型1つあたりのバイト数を返します
+func typeSize(typ uint16) int {
+	switch typ {
+	case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+		return 1
+	case 3, 8: // SHORT, SSHORT
+		return 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		return 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		return 8
+	default:
+		return 0
+	}
+}
+
+// stripPNG はPNGからメタデータのチャンクを取り除きます
+// eXIf（EXIF）とテキストチャンク（XMPを含む）は位置情報を含み得るため取り除きます
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		switch string(data[pos+4 : pos+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt":
+		default:
+			out = append(out, data[pos:end]...)
+		}
+
+		pos = end
+	}
+
+	return out, nil
+}
@@ -0,0 +1,181 @@
+package photo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	// ThumbnailSize はサムネイルの長辺のピクセル数
+	ThumbnailSize = 320
+	// MaxPixels は受け付ける画像の最大ピクセル数（展開時のメモリ使用量を抑えるため）
+	MaxPixels = 40_000_000
+	// thumbnailQuality はサムネイルのJPEG品質
+	thumbnailQuality = 80
+)
+
+var (
+	// ErrUnsupportedFormat はJPEGとPNG以外の画像のエラー
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrImageTooLarge はピクセル数が多すぎる画像のエラー
+	ErrImageTooLarge = errors.New("image is too large")
+)
+
+// Image はアップロード用に処理された画像を表します
+type Image struct {
+	Data        []byte // 位置情報を取り除いた元画像
+	ContentType string // image/jpeg または image/png
+	Width       int    // 向きを補正した表示上の幅
+	Height      int    // 向きを補正した表示上の高さ
+	Thumbnail   []byte // JPEG形式のサムネイル
+}
+
+// Process はアップロードされた画像を検証し、位置情報の除去とサムネイルの生成を行います
+// 元画像は再エンコードせず、メタデータのみを書き換えます
+func Process(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// 展開前にサイズを確認
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	var stripped []byte
+	orientation := 1
+	if contentType == "image/jpeg" {
+		stripped, orientation, err = stripJPEG(data)
+	} else {
+		stripped, err = stripPNG(data)
+	}
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	thumb := orient(resize(src, ThumbnailSize), orientation)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	width, height := config.Width, config.Height
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	return &Image{
+		Data:        stripped,
+		ContentType: contentType,
+		Width:       width,
+		Height:      height,
+		Thumbnail:   buf.Bytes(),
+	}, nil
+}
+
+// resize は長辺がmaxSize以下になるよう画像を縮小します
+// 元画像の各ピクセルを縮小後のピクセルに平均する（エリア平均法）ため、1回の走査で済みます
+// 透過部分は白背景に合成します
+func resize(src image.Image, maxSize int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw > maxSize || sh > maxSize {
+		if sw >= sh {
+			dw, dh = maxSize, max(1, sh*maxSize/sw)
+		} else {
+			dw, dh = max(1, sw*maxSize/sh), maxSize
+		}
+	}
+
+	sums := make([][4]uint64, dw*dh)
+	counts := make([]uint64, dw*dh)
+	for y := 0; y < sh; y++ {
+		row := (y * dh / sh) * dw
+		for x := 0; x < sw; x++ {
+			i := row + x*dw/sw
+			r, g, bl, a := src.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			sums[i][0] += uint64(r)
+			sums[i][1] += uint64(g)
+			sums[i][2] += uint64(bl)
+			sums[i][3] += uint64(a)
+			counts[i]++
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for i, sum := range sums {
+		n := counts[i]
+		if n == 0 {
+			continue
+		}
+		// 乗算済みアルファの値に (1 - alpha) の白を加える
+		white := 0xffff - sum[3]/n
+		dst.Set(i%dw, i/dw, color.RGBA64{
+			R: uint16(sum[0]/n + white),
+			G: uint16(sum[1]/n + white),
+			B: uint16(sum[2]/n + white),
+			A: 0xffff,
+		})
+	}
+
+	return dst
+}
+
+// orient はEXIFのOrientationに従って画像を回転・反転します
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 左上-右下の対角線で反転
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = h-1-y, x
+			case 7: // 右上-左下の対角線で反転
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, w-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,190 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImage はテスト用の単色の画像を作成します
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	return img
+}
+
+// testEXIF はOrientationとGPS緯度を持つリトルエンディアンのEXIFセグメントを作成します
+func testEXIF(orientation uint16) []byte {
+	le := binary.LittleEndian
+	tiff := make([]byte, 80)
+	copy(tiff, "II")
+	le.PutUint16(tiff[2:], 42)
+	le.PutUint32(tiff[4:], 8)
+
+	// IFD0: Orientation と GPSInfo
+	le.PutUint16(tiff[8:], 2)
+	le.PutUint16(tiff[10:], tagOrientation)
+	le.PutUint16(tiff[12:], 3)
+	le.PutUint32(tiff[14:], 1)
+	le.PutUint16(tiff[18:], orientation)
+	le.PutUint16(tiff[22:], tagGPSInfo)
+	le.PutUint16(tiff[24:], 4)
+	le.PutUint32(tiff[26:], 1)
+	le.PutUint32(tiff[30:], 38)
+
+	// GPS IFD: GPSLatitude（RATIONAL x 3）
+	le.PutUint16(tiff[38:], 1)
+	le.PutUint16(tiff[40:], 0x0002)
+	le.PutUint16(tiff[42:], 5)
+	le.PutUint32(tiff[44:], 3)
+	le.PutUint32(tiff[48:], 56)
+	for i, v := range []uint32{35, 1, 41, 1, 2222, 100} {
+		le.PutUint32(tiff[56+i*4:], v)
+	}
+
+	payload := append(append([]byte(nil), exifHeader...), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// testJPEG はEXIFとXMPのセグメントを含むJPEGを作成します
+func testJPEG(t *testing.T, w, h int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(w, h), nil))
+	encoded := buf.Bytes()
+
+	xmp := append([]byte("http://ns.adobe.com/xap/1.0/\x00"), []byte(`<exif:GPSLatitude>35,41.37N</exif:GPSLatitude>`)...)
+	xmpSegment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(xmpSegment[2:], uint16(len(xmp)+2))
+
+	out := append([]byte(nil), encoded[:2]...)
+	out = append(out, testEXIF(orientation)...)
+	out = append(out, xmpSegment...)
+	out = append(out, xmp...)
+	return append(out, encoded[2:]...)
+}
+
+// TestProcess_JPEG はJPEGの位置情報の除去とサムネイル生成のテスト
+func TestProcess_JPEG(t *testing.T) {
+	data := testJPEG(t, 800, 400, 6)
+
+	img, err := Process(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "image/jpeg", img.ContentType)
+	// Orientation=6（90度回転）なので表示上の幅と高さが入れ替わる
+	assert.Equal(t, 400, img.Width)
+	assert.Equal(t, 800, img.Height)
+
+	// GPSの値とXMPが取り除かれている
+	gpsValue := make([]byte, 8)
+	binary.LittleEndian.PutUint32(gpsValue, 2222)
+	binary.LittleEndian.PutUint32(gpsValue[4:], 100)
+	assert.True(t, bytes.Contains(data, gpsValue))
+	assert.False(t, bytes.Contains(img.Data, gpsValue))
+	assert.False(t, bytes.Contains(img.Data, []byte("GPSLatitude")))
+
+	// EXIFは残り、Orientationは保持される
+	stripped, orientation, err := stripJPEG(img.Data)
+	require.NoError(t, err)
+	assert.Equal(t, 6, orientation)
+	assert.Equal(t, img.Data, stripped)
+
+	// 元画像は再エンコードせずにデコードできる
+	decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 800, 400), decoded.Bounds())
+
+	// サムネイルは向きを補正して長辺が320ピクセル
+	thumb, err := jpeg.Decode(bytes.NewReader(img.Thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 160, 320), thumb.Bounds())
+}
+
+// TestProcess_PNG はPNGのメタデータ除去のテスト
+func TestProcess_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(100, 50)))
+	encoded := buf.Bytes()
+
+	// IHDRの直後にtEXtチャンクを挿入
+	text := []byte("Comment\x00GPS 35.68,139.69")
+	chunk := make([]byte, 8, 12+len(text))
+	binary.BigEndian.PutUint32(chunk, uint32(len(text)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	ihdrEnd := 8 + 12 + 13
+	data := append(append(append([]byte(nil), encoded[:ihdrEnd]...), chunk...), encoded[ihdrEnd:]...)
+
+	img, err := Process(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "image/png", img.ContentType)
+	assert.Equal(t, 100, img.Width)
+	assert.Equal(t, 50, img.Height)
+	assert.Equal(t, encoded, img.Data)
+
+	// 長辺が320ピクセル未満の画像は拡大しない
+	thumb, err := jpeg.Decode(bytes.NewReader(img.Thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), thumb.Bounds())
+}
+
+// TestProcess_Invalid は対応していない画像のテスト
+func TestProcess_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"テキスト", []byte("hello, world")},
+		{"GIF", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")},
+		{"壊れたJPEG", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Process(tt.data)
+			assert.ErrorIs(t, err, ErrUnsupportedFormat)
+		})
+	}
+}
+
+// TestOrient はEXIFのOrientationに従った回転のテスト
+func TestOrient(t *testing.T) {
+	// 2x1の画像: 左が赤、右が青
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	// 時計回りに90度回転すると上が赤、下が青
+	rotated := orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Bounds())
+	assert.Equal(t, red, rotated.RGBAAt(0, 0))
+	assert.Equal(t, blue, rotated.RGBAAt(0, 1))
+
+	// 反時計回りに90度回転すると上が青、下が赤
+	rotated = orient(src, 8)
+	assert.Equal(t, blue, rotated.RGBAAt(0, 0))
+	assert.Equal(t, red, rotated.RGBAAt(0, 1))
+
+	// 左右反転
+	flipped := orient(src, 2)
+	assert.Equal(t, blue, flipped.RGBAAt(0, 0))
+	assert.Equal(t, red, flipped.RGBAAt(1, 0))
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// AreaRepository はAreaデータアクセスのインターフェースを定義します
+type AreaRepository interface {
+	Create(ctx context.Context, area *model.Area, ring []model.LatLng) error
+	FindByID(ctx context.Context, id string) (*model.Area, error)
+	FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.Area, error)
+	FindContaining(ctx context.Context, userID string, lat, lng float64) ([]*model.Area, error)
+	Delete(ctx context.Context, id string) error
+}
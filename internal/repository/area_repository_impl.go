@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// areaRepositoryImpl はAreaRepositoryの実装
+type areaRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewAreaRepository は新しいAreaRepositoryインスタンスを作成します
+func NewAreaRepository(db *sqlx.DB) AreaRepository {
+	return &areaRepositoryImpl{
+		db: db,
+	}
+}
+
+// areaColumns はAreaを取得するSELECT句の列
+// 面積と重心はgeographyで計算した測地線上の値
+const areaColumns = `
+			id,
+			user_id,
+			name,
+			pin_ids,
+			ST_AsGeoJSON(polygon) as geometry,
+			ST_Area(polygon::geography) as area_square_meters,
+			ST_Y(ST_Centroid(polygon::geography)::geometry) as centroid_latitude,
+			ST_X(ST_Centroid(polygon::geography)::geometry) as centroid_longitude,
+			created_at`
+
+// scanArea はareaColumnsの順序で1行をスキャンします
+func scanArea(row rowScanner) (*model.Area, error) {
+	var area model.Area
+	var geometry string
+	err := row.Scan(
+		&area.ID,
+		&area.UserID,
+		&area.Name,
+		(*pq.StringArray)(&area.PinIDs),
+		&geometry,
+		&area.AreaSquareMeters,
+		&area.Centroid.Latitude,
+		&area.Centroid.Longitude,
+		&area.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	area.Geometry = []byte(geometry)
+	return &area, nil
+}
+
+// Create は頂点の環からポリゴンを作成し、新しいAreaをデータベースに作成します
+// ringはarea.PinIDsと同じ順序のPinの座標で、始点を繰り返さずに指定します
+func (r *areaRepositoryImpl) Create(ctx context.Context, area *model.Area, ring []model.LatLng) error {
+	// UUIDを生成
+	if area.ID == "" {
+		area.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO areas (id, user_id, name, pin_ids, polygon)
+		VALUES ($1, $2, $3, $4, ST_GeomFromText($5, 4326))
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		area.ID,
+		area.UserID,
+		area.Name,
+		pq.Array(area.PinIDs),
+		polygonWKT(ring),
+	).Scan(&area.ID, &area.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create area: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID はIDでAreaを検索します
+func (r *areaRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Area, error) {
+	query := `
+		SELECT ` + areaColumns + `
+		FROM areas
+		WHERE id = $1
+	`
+
+	area, err := scanArea(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("area not found with id: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find area by id: %w", err)
+	}
+
+	return area, nil
+}
+
+// FindPageByUserID はユーザーIDでAreaを (created_at, id) の降順に最大limit件検索します
+// afterが指定された場合はそのカーソルより後ろの行から取得するキーセットページネーションを行います
+func (r *areaRepositoryImpl) FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.Area, error) {
+	afterCreatedAt, afterID := cursorArgs(after)
+
+	query := `
+		SELECT ` + areaColumns + `
+		FROM areas
+		WHERE user_id = $1
+			AND ($2::timestamp IS NULL OR (created_at, id) < ($2::timestamp, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+
+	return r.queryAreas(ctx, query, userID, afterCreatedAt, afterID, limit)
+}
+
+// FindContaining はユーザーのAreaのうち指定した地点を含むものを新しい順に検索します
+// 境界上の地点も含まれます
+func (r *areaRepositoryImpl) FindContaining(ctx context.Context, userID string, lat, lng float64) ([]*model.Area, error) {
+	query := `
+		SELECT ` + areaColumns + `
+		FROM areas
+		WHERE user_id = $1
+			AND ST_Covers(polygon, ST_SetSRID(ST_MakePoint($2, $3), 4326))
+		ORDER BY created_at DESC, id DESC
+	`
+
+	return r.queryAreas(ctx, query, userID, lng, lat)
+}
+
+// Delete はAreaを削除します
+func (r *areaRepositoryImpl) Delete(ctx context.Context, id string) error {
+	query := `
+		DELETE FROM areas
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete area: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("area not found: %s", id)
+	}
+
+	return nil
+}
+
+// queryAreas はareaColumnsを選択するクエリを実行し、結果をスキャンします
+func (r *areaRepositoryImpl) queryAreas(ctx context.Context, query string, args ...interface{}) ([]*model.Area, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find areas: %w", err)
+	}
+	defer rows.Close()
+
+	var areas []*model.Area
+	for rows.Next() {
+		area, err := scanArea(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan area: %w", err)
+		}
+		areas = append(areas, area)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating areas: %w", err)
+	}
+
+	return areas, nil
+}
+
+// polygonWKT は頂点の環からWKT形式のPOLYGONを組み立てます
+// WKTでは座標を「経度 緯度」の順序で記述し、始点を最後に繰り返して環を閉じます
+func polygonWKT(ring []model.LatLng) string {
+	points := make([]string, 0, len(ring)+1)
+	for i := 0; i <= len(ring); i++ {
+		p := ring[i%len(ring)]
+		points = append(points, strconv.FormatFloat(p.Longitude, 'f', -1, 64)+" "+strconv.FormatFloat(p.Latitude, 'f', -1, 64))
+	}
+	return "POLYGON((" + strings.Join(points, ", ") + "))"
+}
@@ -10,7 +10,11 @@ import (
 type ConnectRepository interface {
 	Create(ctx context.Context, connect *model.Connect) error
 	Update(ctx context.Context, connect *model.Connect) error
-	FindByID(ctx context.Context, id string) (*model.Connect, error)
-	FindByUserID(ctx context.Context, userID string) ([]*model.Connect, error)
+	FindByID(ctx context.Context, id string) (*model.ConnectLine, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*model.ConnectLine, error)
+	FindByUserID(ctx context.Context, userID string) ([]*model.ConnectLine, error)
+	FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.ConnectLine, error)
+	ExistsByPinPair(ctx context.Context, userID, pinID1, pinID2, excludeID string) (bool, error)
+	EachLineByUserID(ctx context.Context, userID string, fn func(*model.ConnectLine) error) error
 	Delete(ctx context.Context, id string) error
 }
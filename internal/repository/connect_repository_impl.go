@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // connectRepositoryImpl はConnectRepositoryの実装
@@ -22,6 +23,56 @@ func NewConnectRepository(db *sqlx.DB) ConnectRepository {
 	}
 }
 
+// connectLineColumns は接続を両端のPinの座標、距離、方位付きで取得するSELECT句の列
+// 距離と方位はgeographyで計算した測地線上の値。両端が同じ座標の場合、方位は0とする
+const connectLineColumns = `
+			c.id,
+			c.user_id,
+			c.pins_id_1,
+			c.pins_id_2,
+			c.show,
+			c.created_at,
+			ST_Y(p1.location) as from_latitude,
+			ST_X(p1.location) as from_longitude,
+			ST_Y(p2.location) as to_latitude,
+			ST_X(p2.location) as to_longitude,
+			ST_Distance(p1.location::geography, p2.location::geography) as distance_meters,
+			COALESCE(degrees(ST_Azimuth(p1.location::geography, p2.location::geography)), 0) as bearing`
+
+// connectLineJoins は接続と両端のPinを結合するFROM句
+// ゴミ箱にあるPinを参照する接続は非表示にし、Pinが復元されると再び表示されます
+// 接続の作成者が閲覧できないPin（他のユーザーの非公開Pin）を参照する接続も、座標を返さないよう非表示にします
+const connectLineJoins = `
+		FROM connect c
+		JOIN pins p1 ON p1.id = c.pins_id_1 AND p1.deleted_at IS NULL
+			AND (p1.visibility = 'public' OR p1.user_id = c.user_id)
+		JOIN pins p2 ON p2.id = c.pins_id_2 AND p2.deleted_at IS NULL
+			AND (p2.visibility = 'public' OR p2.user_id = c.user_id)`
+
+// scanConnectLine はconnectLineColumnsの順序で1行をスキャンします
+func scanConnectLine(row rowScanner) (*model.ConnectLine, error) {
+	line := model.ConnectLine{Connect: &model.Connect{}}
+	err := row.Scan(
+		&line.ID,
+		&line.UserID,
+		&line.PinID1,
+		&line.PinID2,
+		&line.Show,
+		&line.CreatedAt,
+		&line.From.Latitude,
+		&line.From.Longitude,
+		&line.To.Latitude,
+		&line.To.Longitude,
+		&line.DistanceMeters,
+		&line.Bearing,
+	)
+	if err != nil {
+		return nil, err
+	}
+	line.Geometry = line.LineString()
+	return &line, nil
+}
+
 // Create は新しい接続をデータベースに作成します
 // 要件: 8.1, 8.2, 8.3
 func (r *connectRepositoryImpl) Create(ctx context.Context, connect *model.Connect) error {
@@ -33,7 +84,7 @@ func (r *connectRepositoryImpl) Create(ctx context.Context, connect *model.Conne
 	query := `
 		INSERT INTO connect (id, user_id, pins_id_1, pins_id_2, show)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
+		RETURNING id, created_at
 	`
 
 	err := r.db.QueryRowContext(
@@ -44,7 +95,7 @@ func (r *connectRepositoryImpl) Create(ctx context.Context, connect *model.Conne
 		connect.PinID1,
 		connect.PinID2,
 		connect.Show,
-	).Scan(&connect.ID)
+	).Scan(&connect.ID, &connect.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create connect: %w", err)
@@ -87,18 +138,16 @@ func (r *connectRepositoryImpl) Update(ctx context.Context, connect *model.Conne
 	return nil
 }
 
-// FindByID はIDで接続を検索します
+// FindByID はIDで接続を両端のPinの座標付きで検索します
+// ゴミ箱にあるPinを参照する接続は見つからないものとして扱います
 // 要件: 8.1, 9.1
-func (r *connectRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Connect, error) {
-	var connect model.Connect
-
+func (r *connectRepositoryImpl) FindByID(ctx context.Context, id string) (*model.ConnectLine, error) {
 	query := `
-		SELECT id, user_id, pins_id_1, pins_id_2, show
-		FROM connect
-		WHERE id = $1
+		SELECT ` + connectLineColumns + connectLineJoins + `
+		WHERE c.id = $1
 	`
 
-	err := r.db.GetContext(ctx, &connect, query, id)
+	line, err := scanConnectLine(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("connect not found with id: %s", id)
@@ -106,27 +155,148 @@ func (r *connectRepositoryImpl) FindByID(ctx context.Context, id string) (*model
 		return nil, fmt.Errorf("failed to find connect by id: %w", err)
 	}
 
-	return &connect, nil
+	return line, nil
 }
 
-// FindByUserID はユーザーIDで接続一覧を検索します
+// FindByIDs は複数のIDで接続を両端のPinの座標付きでまとめて検索します
+// 見つからないIDやゴミ箱にあるPinを参照する接続は結果に含まれません
+func (r *connectRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*model.ConnectLine, error) {
+	var lines []*model.ConnectLine
+	if len(ids) == 0 {
+		return lines, nil
+	}
+
+	query := `
+		SELECT ` + connectLineColumns + connectLineJoins + `
+		WHERE c.id = ANY($1::uuid[])
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connects by ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, err := scanConnectLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan connect: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating connects: %w", err)
+	}
+
+	return lines, nil
+}
+
+// FindByUserID はユーザーIDで接続一覧を両端のPinの座標付きで検索します
 // 要件: 8.1, 9.1
-func (r *connectRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*model.Connect, error) {
-	var connects []*model.Connect
+func (r *connectRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*model.ConnectLine, error) {
+	lines := []*model.ConnectLine{}
+	err := r.EachLineByUserID(ctx, userID, func(line *model.ConnectLine) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connects by user id: %w", err)
+	}
+
+	return lines, nil
+}
+
+// FindPageByUserID はユーザーIDで接続を (created_at, id) の降順に最大limit件、両端のPinの座標付きで検索します
+// afterが指定された場合はそのカーソルより後ろの行から取得するキーセットページネーションを行います
+func (r *connectRepositoryImpl) FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.ConnectLine, error) {
+	afterCreatedAt, afterID := cursorArgs(after)
 
 	query := `
-		SELECT id, user_id, pins_id_1, pins_id_2, show
-		FROM connect
-		WHERE user_id = $1
-		ORDER BY id
+		SELECT ` + connectLineColumns + connectLineJoins + `
+		WHERE c.user_id = $1
+			AND ($2::timestamp IS NULL OR (c.created_at, c.id) < ($2::timestamp, $3::uuid))
+		ORDER BY c.created_at DESC, c.id DESC
+		LIMIT $4
 	`
 
-	err := r.db.SelectContext(ctx, &connects, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, afterCreatedAt, afterID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find connects by user id: %w", err)
 	}
+	defer rows.Close()
+
+	var lines []*model.ConnectLine
+	for rows.Next() {
+		line, err := scanConnectLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan connect: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating connects: %w", err)
+	}
+
+	return lines, nil
+}
 
-	return connects, nil
+// ExistsByPinPair はユーザーが同じPinの組み合わせの接続を既に持っているかを確認します
+// Pinの順序は区別せず、ゴミ箱にあるPinを参照する接続も含めます（一意インデックスと同じ条件）
+// excludeIDが指定された場合はその接続を除外します
+func (r *connectRepositoryImpl) ExistsByPinPair(ctx context.Context, userID, pinID1, pinID2, excludeID string) (bool, error) {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM connect
+			WHERE user_id = $1
+				AND LEAST(pins_id_1, pins_id_2) = LEAST($2::uuid, $3::uuid)
+				AND GREATEST(pins_id_1, pins_id_2) = GREATEST($2::uuid, $3::uuid)
+				AND id::text <> $4
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &exists, query, userID, pinID1, pinID2, excludeID); err != nil {
+		return false, fmt.Errorf("failed to check connect existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// EachLineByUserID はユーザーIDで接続を検索し、両端のPinの座標付きで1件ずつfnを呼び出します
+// pinsテーブルと結合し、削除済みのPinを参照している接続は除外します
+// fnがエラーを返した場合は処理を中断してそのエラーを返します
+func (r *connectRepositoryImpl) EachLineByUserID(ctx context.Context, userID string, fn func(*model.ConnectLine) error) error {
+	query := `
+		SELECT ` + connectLineColumns + connectLineJoins + `
+		WHERE c.user_id = $1
+		ORDER BY c.created_at DESC, c.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find connect lines by user id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, err := scanConnectLine(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan connect line: %w", err)
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating connect lines: %w", err)
+	}
+
+	return nil
 }
 
 // Delete は接続を削除します
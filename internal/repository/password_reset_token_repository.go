@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// PasswordResetTokenRepository はパスワード再設定用トークンのデータアクセスのインターフェースを定義します
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *model.PasswordResetToken, ttl time.Duration) error
+	HasRecentUnused(ctx context.Context, userID string, age time.Duration) (bool, error)
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (*model.PasswordResetToken, error)
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// passwordResetTokenRepositoryImpl はPasswordResetTokenRepositoryの実装
+type passwordResetTokenRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewPasswordResetTokenRepository は新しいPasswordResetTokenRepositoryインスタンスを作成します
+func NewPasswordResetTokenRepository(db *sqlx.DB) PasswordResetTokenRepository {
+	return &passwordResetTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は新しいパスワード再設定用トークンを作成します
+// 有効期限はデータベースの時刻を基準にttl秒後とします
+func (r *passwordResetTokenRepositoryImpl) Create(ctx context.Context, token *model.PasswordResetToken, ttl time.Duration) error {
+	// UUIDを生成
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4 * INTERVAL '1 second')
+		RETURNING expires_at, created_at
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		ttl.Seconds(),
+	).Scan(&token.ExpiresAt, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// HasRecentUnused はユーザーに作成からage以内の有効期限内の未使用のトークンがあるかを返します
+func (r *passwordResetTokenRepositoryImpl) HasRecentUnused(ctx context.Context, userID string, age time.Duration) (bool, error) {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM password_reset_tokens
+			WHERE user_id = $1 AND used_at IS NULL AND expires_at > NOW()
+				AND created_at > NOW() - $2 * INTERVAL '1 second'
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &exists, query, userID, age.Seconds()); err != nil {
+		return false, fmt.Errorf("failed to check recent password reset token: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ResetPassword はハッシュで有効期限内の未使用のトークンを使用済みにし、ユーザーのパスワードを変更します
+// 同じユーザーの他の未使用のトークンも無効化します
+// 途中で失敗した場合にトークンだけが消費されないよう、全ての更新を1つのトランザクションで行います
+// 既存のセッションの失効は呼び出し側で行ってください
+func (r *passwordResetTokenRepositoryImpl) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (*model.PasswordResetToken, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 同時に使用された場合も1回のみ成功するよう、検索と更新を1つのUPDATE文で行う
+	var token model.PasswordResetToken
+	consumeQuery := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, user_id, token_hash, expires_at, used_at, created_at
+	`
+	if err := tx.GetContext(ctx, &token, consumeQuery, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("password reset token not found")
+		}
+		return nil, fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	userQuery := `
+		UPDATE users
+		SET password = $1, updated_at = NOW()
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, userQuery, passwordHash, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("user not found or already deleted: %s", token.UserID)
+	}
+
+	invalidateQuery := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, invalidateQuery, token.UserID); err != nil {
+		return nil, fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// PhotoRepository は写真データアクセスのインターフェースを定義します
+type PhotoRepository interface {
+	Create(ctx context.Context, photo *model.Photo, maxPhotos int) (bool, error)
+	FindByID(ctx context.Context, id string) (*model.Photo, error)
+	FindByPinID(ctx context.Context, pinID string) ([]*model.Photo, error)
+	CountByPinID(ctx context.Context, pinID string) (int, error)
+	SoftDelete(ctx context.Context, id string) error
+}
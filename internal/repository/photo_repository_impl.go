@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// photoRepositoryImpl はPhotoRepositoryの実装
+type photoRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewPhotoRepository は新しいPhotoRepositoryインスタンスを作成します
+func NewPhotoRepository(db *sqlx.DB) PhotoRepository {
+	return &photoRepositoryImpl{
+		db: db,
+	}
+}
+
+// photoColumns は写真を取得するSELECT句の列
+const photoColumns = `
+			id,
+			pin_id,
+			user_id,
+			storage_key,
+			thumbnail_key,
+			content_type,
+			width,
+			height,
+			size_bytes,
+			created_at,
+			deleted_at`
+
+// Create は新しい写真をデータベースに作成します
+// 親のPinを行ロックしてから枚数を数えるため、同時に追加されてもPinの写真はmaxPhotos枚を超えません
+// 既にmaxPhotos枚ある場合は何もせずfalseを返し、親のPinが削除されている場合はエラーになります
+func (r *photoRepositoryImpl) Create(ctx context.Context, photo *model.Photo, maxPhotos int) (bool, error) {
+	// UUIDを生成
+	if photo.ID == "" {
+		photo.ID = uuid.New().String()
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var pinID string
+	lockQuery := `
+		SELECT id
+		FROM pins
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &pinID, lockQuery, photo.PinID); err != nil {
+		if err == sql.ErrNoRows {
+			return false, fmt.Errorf("pin not found or already deleted: %s", photo.PinID)
+		}
+		return false, fmt.Errorf("failed to lock pin: %w", err)
+	}
+
+	query := `
+		INSERT INTO photos (id, pin_id, user_id, storage_key, thumbnail_key, content_type, width, height, size_bytes)
+		SELECT $1::uuid, $2::uuid, $3::uuid, $4::text, $5::text, $6::text, $7::integer, $8::integer, $9::bigint
+		WHERE (SELECT COUNT(*) FROM photos WHERE pin_id = $2::uuid AND deleted_at IS NULL) < $10
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		photo.ID,
+		photo.PinID,
+		photo.UserID,
+		photo.StorageKey,
+		photo.ThumbnailKey,
+		photo.ContentType,
+		photo.Width,
+		photo.Height,
+		photo.SizeBytes,
+		maxPhotos,
+	).Scan(&photo.ID, &photo.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create photo: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// FindByID はIDで写真を検索します
+func (r *photoRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Photo, error) {
+	var photo model.Photo
+
+	query := `
+		SELECT ` + photoColumns + `
+		FROM photos
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	err := r.db.GetContext(ctx, &photo, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("photo not found with id: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find photo by id: %w", err)
+	}
+
+	return &photo, nil
+}
+
+// FindByPinID はPinの写真を追加された順に検索します
+func (r *photoRepositoryImpl) FindByPinID(ctx context.Context, pinID string) ([]*model.Photo, error) {
+	var photos []*model.Photo
+
+	query := `
+		SELECT ` + photoColumns + `
+		FROM photos
+		WHERE pin_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	err := r.db.SelectContext(ctx, &photos, query, pinID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find photos by pin id: %w", err)
+	}
+
+	return photos, nil
+}
+
+// CountByPinID はPinの写真の枚数を数えます
+func (r *photoRepositoryImpl) CountByPinID(ctx context.Context, pinID string) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM photos
+		WHERE pin_id = $1 AND deleted_at IS NULL
+	`
+
+	if err := r.db.GetContext(ctx, &count, query, pinID); err != nil {
+		return 0, fmt.Errorf("failed to count photos: %w", err)
+	}
+
+	return count, nil
+}
+
+// SoftDelete は写真を論理削除します
+func (r *photoRepositoryImpl) SoftDelete(ctx context.Context, id string) error {
+	query := `
+		UPDATE photos
+		SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete photo: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("photo not found or already deleted: %s", id)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 )
@@ -9,8 +10,26 @@ import (
 // PinRepository はピンデータアクセスのインターフェースを定義します
 type PinRepository interface {
 	Create(ctx context.Context, pin *model.Pin) error
-	Update(ctx context.Context, pin *model.Pin) error
+	CreateBatch(ctx context.Context, pins []*model.Pin) error
+	Update(ctx context.Context, pin *model.Pin, editorID string) error
+	UpdateVisibility(ctx context.Context, pin *model.Pin) error
+	FindRevisionPage(ctx context.Context, pinID string, before, limit int) ([]*model.PinRevision, error)
+	FindRevision(ctx context.Context, pinID string, revision int) (*model.PinRevision, error)
 	FindByID(ctx context.Context, id string) (*model.Pin, error)
-	FindByUserID(ctx context.Context, userID string) ([]*model.Pin, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Pin, error)
+	FindByShareToken(ctx context.Context, token string) (*model.Pin, error)
+	FindByUserID(ctx context.Context, userID string, filter model.PinFilter) ([]*model.Pin, error)
+	FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, filter model.PinFilter, limit int) ([]*model.Pin, error)
+	FindPublicPage(ctx context.Context, after *model.PageCursor, limit int) ([]*model.Pin, error)
+	EachByUserID(ctx context.Context, userID string, fn func(*model.Pin) error) error
+	FindNearby(ctx context.Context, userID string, lat, lng, radiusMeters float64, filter model.PinFilter, limit int) ([]*model.NearbyPin, error)
+	FindWithinBounds(ctx context.Context, userID string, bbox model.BoundingBox, filter model.PinFilter, limit int) ([]*model.Pin, error)
+	FindClusters(ctx context.Context, userID string, bbox model.BoundingBox, gridSize float64) ([]*model.PinCluster, error)
+	FindTile(ctx context.Context, z, x, y int, viewerID, userID string) ([]byte, error)
 	SoftDelete(ctx context.Context, id string) error
+	FindDeletedByID(ctx context.Context, id string) (*model.Pin, error)
+	FindTrashPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.Pin, error)
+	Restore(ctx context.Context, id string) error
+	HardDelete(ctx context.Context, id string) ([]string, error)
+	FindExpiredTrashIDs(ctx context.Context, retention time.Duration, limit int) ([]string, error)
 }
@@ -3,11 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/lib/pq"
 )
 
 // pinRepositoryImpl はPinRepositoryの実装
@@ -22,6 +26,104 @@ func NewPinRepository(db *sqlx.DB) PinRepository {
 	}
 }
 
+// pinColumns はPinを取得するSELECT句の列
+// scanPinで読み取る順序と一致させる必要があります
+// レビューの集計（ratings）を読み取るため、FROM句のpinsの後ろにpinRatingsJoinを続けて使用します
+const pinColumns = `
+			id,
+			name,
+			user_id,
+			ST_X(location) as longitude,
+			ST_Y(location) as latitude,
+			created_at,
+			edit_at,
+			deleted_at,
+			visibility,
+			share_token,
+			wheelchair_accessible,
+			baby_changing,
+			gender_neutral,
+			washlet,
+			paid,
+			fee_amount,
+			opening_hours,
+			timezone,
+			pin_ratings.ratings`
+
+// pinRatingsJoin はPinに対する削除されていないレビューの集計をJSONで結合するLATERAL JOIN
+// pinsテーブルに別名を付けずに使用します。model.PinRatingsの形式と一致させる必要があります
+var pinRatingsJoin = `
+		LEFT JOIN LATERAL (
+			SELECT json_build_object(
+				'count', COUNT(*),
+				'cleanliness', ` + scoreSummarySQL("cleanliness") + `,
+				'crowding', ` + scoreSummarySQL("crowding") + `,
+				'paper_availability', ` + scoreSummarySQL("paper_availability") + `
+			) as ratings
+			FROM reviews
+			WHERE reviews.pin_id = pins.id AND reviews.deleted_at IS NULL
+		) pin_ratings ON true`
+
+// scoreSummarySQL は評価項目の平均値とスコアごとの件数をmodel.ScoreSummaryの形式のJSONで返す式を組み立てます
+func scoreSummarySQL(column string) string {
+	counts := make([]string, 0, model.MaxReviewScore-model.MinReviewScore+1)
+	for score := model.MinReviewScore; score <= model.MaxReviewScore; score++ {
+		counts = append(counts, fmt.Sprintf("COUNT(*) FILTER (WHERE %s = %d)", column, score))
+	}
+	return fmt.Sprintf("json_build_object('mean', AVG(%s), 'histogram', json_build_array(%s))",
+		column, strings.Join(counts, ", "))
+}
+
+// rowScanner は*sql.Rowと*sql.Rowsに共通のScanメソッドを表します
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPin はpinColumnsの列を読み取りPinを作成します
+// extraにはpinColumnsの後ろに続く追加の列の格納先を指定します
+func scanPin(row rowScanner, extra ...interface{}) (*model.Pin, error) {
+	var pin model.Pin
+	var ratings []byte
+	dest := []interface{}{
+		&pin.ID,
+		&pin.Name,
+		&pin.UserID,
+		&pin.Longitude,
+		&pin.Latitude,
+		&pin.CreatedAt,
+		&pin.EditedAt,
+		&pin.DeletedAt,
+		&pin.Visibility,
+		&pin.ShareToken,
+		&pin.WheelchairAccessible,
+		&pin.BabyChanging,
+		&pin.GenderNeutral,
+		&pin.Washlet,
+		&pin.Paid,
+		&pin.FeeAmount,
+		&pin.OpeningHours,
+		&pin.Timezone,
+		&ratings,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(ratings, &pin.Ratings); err != nil {
+		return nil, fmt.Errorf("failed to decode pin ratings: %w", err)
+	}
+	return &pin, nil
+}
+
+// insertPinQuery はPinを作成するINSERT文
+const insertPinQuery = `
+		INSERT INTO pins (
+			id, name, user_id, location, created_at, edit_at,
+			wheelchair_accessible, baby_changing, gender_neutral, washlet, paid, fee_amount, opening_hours, timezone
+		)
+		VALUES ($1, $2, $3, ST_SetSRID(ST_MakePoint($4, $5), 4326), NOW(), NOW(), $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at, edit_at, visibility
+	`
+
 // Create は新しいPinをデータベースに作成します
 // PostGISのST_MakePointを使用して位置情報を保存
 // 要件: 6.1, 6.2, 6.3, 6.4
@@ -31,21 +133,23 @@ func (r *pinRepositoryImpl) Create(ctx context.Context, pin *model.Pin) error {
 		pin.ID = uuid.New().String()
 	}
 
-	query := `
-		INSERT INTO pins (id, name, user_id, location, created_at, edit_at)
-		VALUES ($1, $2, $3, ST_SetSRID(ST_MakePoint($4, $5), 4326), NOW(), NOW())
-		RETURNING id, created_at, edit_at
-	`
-
 	err := r.db.QueryRowContext(
 		ctx,
-		query,
+		insertPinQuery,
 		pin.ID,
 		pin.Name,
 		pin.UserID,
 		pin.Longitude, // ST_MakePoint(longitude, latitude)の順序
 		pin.Latitude,
-	).Scan(&pin.ID, &pin.CreatedAt, &pin.EditedAt)
+		pin.WheelchairAccessible,
+		pin.BabyChanging,
+		pin.GenderNeutral,
+		pin.Washlet,
+		pin.Paid,
+		pin.FeeAmount,
+		pin.OpeningHours,
+		pin.Timezone,
+	).Scan(&pin.ID, &pin.CreatedAt, &pin.EditedAt, &pin.Visibility)
 
 	if err != nil {
 		return fmt.Errorf("failed to create pin: %w", err)
@@ -54,24 +158,118 @@ func (r *pinRepositoryImpl) Create(ctx context.Context, pin *model.Pin) error {
 	return nil
 }
 
+// CreateBatch は複数のPinを1つのトランザクションで作成します
+// いずれかの作成に失敗した場合は全てロールバックします
+func (r *pinRepositoryImpl) CreateBatch(ctx context.Context, pins []*model.Pin) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PreparexContext(ctx, insertPinQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare pin insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, pin := range pins {
+		// UUIDを生成
+		if pin.ID == "" {
+			pin.ID = uuid.New().String()
+		}
+
+		err := stmt.QueryRowContext(
+			ctx,
+			pin.ID,
+			pin.Name,
+			pin.UserID,
+			pin.Longitude, // ST_MakePoint(longitude, latitude)の順序
+			pin.Latitude,
+			pin.WheelchairAccessible,
+			pin.BabyChanging,
+			pin.GenderNeutral,
+			pin.Washlet,
+			pin.Paid,
+			pin.FeeAmount,
+			pin.OpeningHours,
+			pin.Timezone,
+		).Scan(&pin.ID, &pin.CreatedAt, &pin.EditedAt, &pin.Visibility)
+		if err != nil {
+			return fmt.Errorf("failed to create pin: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // Update はPinの情報を更新します
 // PostGISのST_MakePointを使用して位置情報を更新
+// 更新前後の名前と位置をeditorIDとともにpin_revisionsに同じトランザクションで記録します
 // 要件: 7.1, 7.2, 7.3
-func (r *pinRepositoryImpl) Update(ctx context.Context, pin *model.Pin) error {
+func (r *pinRepositoryImpl) Update(ctx context.Context, pin *model.Pin, editorID string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 更新前の名前と位置を取得（同時に更新されないよう行をロックする）
+	var old struct {
+		Name      string  `db:"name"`
+		Latitude  float64 `db:"latitude"`
+		Longitude float64 `db:"longitude"`
+	}
+	lockQuery := `
+		SELECT name, ST_Y(location) AS latitude, ST_X(location) AS longitude
+		FROM pins
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &old, lockQuery, pin.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("pin not found or already deleted: %s", pin.ID)
+		}
+		return fmt.Errorf("failed to lock pin: %w", err)
+	}
+
 	query := `
 		UPDATE pins
-		SET name = $1, location = ST_SetSRID(ST_MakePoint($2, $3), 4326), edit_at = NOW()
+		SET
+			name = $1,
+			location = ST_SetSRID(ST_MakePoint($2, $3), 4326),
+			wheelchair_accessible = $5,
+			baby_changing = $6,
+			gender_neutral = $7,
+			washlet = $8,
+			paid = $9,
+			fee_amount = $10,
+			opening_hours = $11,
+			timezone = $12,
+			edit_at = NOW()
 		WHERE id = $4 AND deleted_at IS NULL
 		RETURNING edit_at
 	`
 
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		pin.Name,
 		pin.Longitude, // ST_MakePoint(longitude, latitude)の順序
 		pin.Latitude,
 		pin.ID,
+		pin.WheelchairAccessible,
+		pin.BabyChanging,
+		pin.GenderNeutral,
+		pin.Washlet,
+		pin.Paid,
+		pin.FeeAmount,
+		pin.OpeningHours,
+		pin.Timezone,
 	).Scan(&pin.EditedAt)
 
 	if err != nil {
@@ -81,6 +279,118 @@ func (r *pinRepositoryImpl) Update(ctx context.Context, pin *model.Pin) error {
 		return fmt.Errorf("failed to update pin: %w", err)
 	}
 
+	// 編集履歴の記録（Pinの行をロックしているためリビジョン番号は重複しない）
+	revisionQuery := `
+		INSERT INTO pin_revisions (pin_id, revision, editor_id, old_name, new_name, old_location, new_location)
+		SELECT
+			$1::uuid,
+			COALESCE(MAX(revision), 0) + 1,
+			$2::uuid,
+			$3::text,
+			$4::text,
+			ST_SetSRID(ST_MakePoint($5, $6), 4326),
+			ST_SetSRID(ST_MakePoint($7, $8), 4326)
+		FROM pin_revisions
+		WHERE pin_id = $1
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		revisionQuery,
+		pin.ID,
+		editorID,
+		old.Name,
+		pin.Name,
+		old.Longitude,
+		old.Latitude,
+		pin.Longitude,
+		pin.Latitude,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record pin revision: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// revisionColumns はPinの編集履歴を取得するSELECT句の列
+const revisionColumns = `
+			id,
+			pin_id,
+			revision,
+			editor_id,
+			old_name,
+			ST_Y(old_location) AS old_latitude,
+			ST_X(old_location) AS old_longitude,
+			new_name,
+			ST_Y(new_location) AS new_latitude,
+			ST_X(new_location) AS new_longitude,
+			created_at`
+
+// FindRevisionPage はPinの編集履歴をリビジョン番号の降順に最大limit件検索します
+// beforeが0より大きい場合はそのリビジョン番号より前の行から取得するキーセットページネーションを行います
+func (r *pinRepositoryImpl) FindRevisionPage(ctx context.Context, pinID string, before, limit int) ([]*model.PinRevision, error) {
+	var revisions []*model.PinRevision
+
+	query := `
+		SELECT ` + revisionColumns + `
+		FROM pin_revisions
+		WHERE pin_id = $1
+			AND ($2 = 0 OR revision < $2)
+		ORDER BY revision DESC
+		LIMIT $3
+	`
+
+	err := r.db.SelectContext(ctx, &revisions, query, pinID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pin revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// FindRevision はPinの指定された番号のリビジョンを検索します
+func (r *pinRepositoryImpl) FindRevision(ctx context.Context, pinID string, revision int) (*model.PinRevision, error) {
+	var rev model.PinRevision
+
+	query := `
+		SELECT ` + revisionColumns + `
+		FROM pin_revisions
+		WHERE pin_id = $1 AND revision = $2
+	`
+
+	err := r.db.GetContext(ctx, &rev, query, pinID, revision)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("pin revision not found: %s#%d", pinID, revision)
+		}
+		return nil, fmt.Errorf("failed to find pin revision: %w", err)
+	}
+
+	return &rev, nil
+}
+
+// UpdateVisibility はPinの公開範囲と共有リンク用トークンを更新します
+func (r *pinRepositoryImpl) UpdateVisibility(ctx context.Context, pin *model.Pin) error {
+	query := `
+		UPDATE pins
+		SET visibility = $1, share_token = $2, edit_at = NOW()
+		WHERE id = $3 AND deleted_at IS NULL
+		RETURNING edit_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, pin.Visibility, pin.ShareToken, pin.ID).Scan(&pin.EditedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("pin not found or already deleted: %s", pin.ID)
+		}
+		return fmt.Errorf("failed to update pin visibility: %w", err)
+	}
+
 	return nil
 }
 
@@ -88,33 +398,13 @@ func (r *pinRepositoryImpl) Update(ctx context.Context, pin *model.Pin) error {
 // PostGISのST_X/ST_Yを使用して緯度経度を抽出
 // 要件: 6.1
 func (r *pinRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Pin, error) {
-	var pin model.Pin
-
 	query := `
-		SELECT
-			id,
-			name,
-			user_id,
-			ST_X(location) as longitude,
-			ST_Y(location) as latitude,
-			created_at,
-			edit_at,
-			deleted_at
-		FROM pins
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&pin.ID,
-		&pin.Name,
-		&pin.UserID,
-		&pin.Longitude,
-		&pin.Latitude,
-		&pin.CreatedAt,
-		&pin.EditedAt,
-		&pin.DeletedAt,
-	)
-
+	pin, err := scanPin(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("pin not found with id: %s", id)
@@ -122,52 +412,295 @@ func (r *pinRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Pin
 		return nil, fmt.Errorf("failed to find pin by id: %w", err)
 	}
 
-	return &pin, nil
+	return pin, nil
+}
+
+// FindByIDs は複数のIDでPinをまとめて検索します
+// 見つからないIDや削除済みのPinは結果に含まれません
+func (r *pinRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*model.Pin, error) {
+	var pins []*model.Pin
+	if len(ids) == 0 {
+		return pins, nil
+	}
+
+	query := `
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
+		WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pins by ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// FindByShareToken は共有リンク用トークンでPinを検索します
+func (r *pinRepositoryImpl) FindByShareToken(ctx context.Context, token string) (*model.Pin, error) {
+	query := `
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
+		WHERE share_token = $1 AND deleted_at IS NULL
+	`
+
+	pin, err := scanPin(r.db.QueryRowContext(ctx, query, token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("pin not found with share token")
+		}
+		return nil, fmt.Errorf("failed to find pin by share token: %w", err)
+	}
+
+	return pin, nil
 }
 
 // FindByUserID はユーザーIDで全てのPinを検索します
 // PostGISのST_X/ST_Yを使用して緯度経度を抽出
+// filterで設備属性による絞り込みを行います
 // 要件: 6.1
-func (r *pinRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]*model.Pin, error) {
+func (r *pinRepositoryImpl) FindByUserID(ctx context.Context, userID string, filter model.PinFilter) ([]*model.Pin, error) {
+	var pins []*model.Pin
+
+	args := []interface{}{userID}
+	condition, args := filterCondition(filter, args)
+
+	query := fmt.Sprintf(`
+		SELECT `+pinColumns+`
+		FROM pins `+pinRatingsJoin+`
+		WHERE user_id = $1 AND deleted_at IS NULL AND %s
+		ORDER BY created_at DESC, id DESC
+	`, condition)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pins by user id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// FindPageByUserID はユーザーIDでPinを (created_at, id) の降順に最大limit件検索します
+// afterが指定された場合はそのカーソルより後ろの行から取得するキーセットページネーションを行います
+// filterで設備属性による絞り込みを行います
+func (r *pinRepositoryImpl) FindPageByUserID(ctx context.Context, userID string, after *model.PageCursor, filter model.PinFilter, limit int) ([]*model.Pin, error) {
 	var pins []*model.Pin
 
+	afterCreatedAt, afterID := cursorArgs(after)
+	args := []interface{}{userID, afterCreatedAt, afterID}
+	condition, args := filterCondition(filter, args)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+pinColumns+`
+		FROM pins `+pinRatingsJoin+`
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+			AND ($2::timestamp IS NULL OR (created_at, id) < ($2::timestamp, $3::uuid))
+			AND %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, condition, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pins by user id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// FindPublicPage は公開範囲がpublicのPinを (created_at, id) の降順に最大limit件検索します
+// afterが指定された場合はそのカーソルより後ろの行から取得するキーセットページネーションを行います
+func (r *pinRepositoryImpl) FindPublicPage(ctx context.Context, after *model.PageCursor, limit int) ([]*model.Pin, error) {
+	var pins []*model.Pin
+
+	afterCreatedAt, afterID := cursorArgs(after)
+
 	query := `
-		SELECT
-			id,
-			name,
-			user_id,
-			ST_X(location) as longitude,
-			ST_Y(location) as latitude,
-			created_at,
-			edit_at,
-			deleted_at
-		FROM pins
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
+		WHERE visibility = 'public'
+			AND deleted_at IS NULL
+			AND ($1::timestamp IS NULL OR (created_at, id) < ($1::timestamp, $2::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public pins: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// EachByUserID はユーザーIDで全てのPinを検索し、1件ずつfnを呼び出します
+// 結果を全てメモリに保持せずに逐次処理するためのメソッド
+// fnがエラーを返した場合は処理を中断してそのエラーを返します
+func (r *pinRepositoryImpl) EachByUserID(ctx context.Context, userID string, fn func(*model.Pin) error) error {
+	query := `
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
 		WHERE user_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find pins by user id: %w", err)
+		return fmt.Errorf("failed to find pins by user id: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var pin model.Pin
-		err := rows.Scan(
-			&pin.ID,
-			&pin.Name,
-			&pin.UserID,
-			&pin.Longitude,
-			&pin.Latitude,
-			&pin.CreatedAt,
-			&pin.EditedAt,
-			&pin.DeletedAt,
-		)
+		pin, err := scanPin(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan pin: %w", err)
+		}
+		if err := fn(pin); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return nil
+}
+
+// FindNearby は指定地点から半径radiusMeters以内のユーザーのPinを近い順に検索します
+// geography型に変換してST_DWithin/ST_Distanceで測地線距離（メートル）を計算
+// filterで設備属性による絞り込みを行います
+func (r *pinRepositoryImpl) FindNearby(ctx context.Context, userID string, lat, lng, radiusMeters float64, filter model.PinFilter, limit int) ([]*model.NearbyPin, error) {
+	var pins []*model.NearbyPin
+
+	// ST_MakePoint(longitude, latitude)の順序
+	args := []interface{}{userID, lng, lat, radiusMeters}
+	condition, args := filterCondition(filter, args)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+pinColumns+`,
+			ST_Distance(location::geography, ST_SetSRID(ST_MakePoint($2, $3), 4326)::geography) as distance
+		FROM pins `+pinRatingsJoin+`
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+			AND ST_DWithin(location::geography, ST_SetSRID(ST_MakePoint($2, $3), 4326)::geography, $4)
+			AND %s
+		ORDER BY distance, id
+		LIMIT $%d
+	`, condition, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nearby pins: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var distance float64
+		pin, err := scanPin(rows, &distance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan nearby pin: %w", err)
+		}
+		pins = append(pins, &model.NearbyPin{Pin: *pin, DistanceMeters: distance})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating nearby pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// FindWithinBounds はバウンディングボックス内のユーザーのPinを検索します
+// ST_MakeEnvelopeで範囲を作成し、日付変更線をまたぐ場合は東西2つの範囲に分割して検索
+// filterで設備属性による絞り込みを行います
+func (r *pinRepositoryImpl) FindWithinBounds(ctx context.Context, userID string, bbox model.BoundingBox, filter model.PinFilter, limit int) ([]*model.Pin, error) {
+	var pins []*model.Pin
+
+	args := []interface{}{userID}
+	bounds, args := boundsCondition(bbox, args)
+	condition, args := filterCondition(filter, args)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT `+pinColumns+`
+		FROM pins `+pinRatingsJoin+`
+		WHERE user_id = $1
+			AND deleted_at IS NULL
+			AND %s
+			AND %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, bounds, condition, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pins within bounds: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pin: %w", err)
 		}
-		pins = append(pins, &pin)
+		pins = append(pins, pin)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -177,16 +710,152 @@ func (r *pinRepositoryImpl) FindByUserID(ctx context.Context, userID string) ([]
 	return pins, nil
 }
 
+// FindClusters はバウンディングボックス内のユーザーのPinをグリッド単位で集約します
+// ST_SnapToGridでgridSize度のセルに分類し、セルごとの重心と件数を返す
+// 件数が1のクラスタには実際のPinを含める
+func (r *pinRepositoryImpl) FindClusters(ctx context.Context, userID string, bbox model.BoundingBox, gridSize float64) ([]*model.PinCluster, error) {
+	var clusters []*model.PinCluster
+
+	args := []interface{}{userID, gridSize}
+	condition, args := boundsCondition(bbox, args)
+
+	query := fmt.Sprintf(`
+		WITH clusters AS (
+			SELECT
+				COUNT(*) as count,
+				ST_Centroid(ST_Collect(location)) as center,
+				MIN(id::text) as any_id
+			FROM pins
+			WHERE user_id = $1
+				AND deleted_at IS NULL
+				AND %s
+			GROUP BY ST_SnapToGrid(location, $2)
+		)
+		SELECT
+			c.count,
+			ST_X(c.center) as longitude,
+			ST_Y(c.center) as latitude,
+			p.id,
+			p.name,
+			p.user_id,
+			ST_X(p.location) as pin_longitude,
+			ST_Y(p.location) as pin_latitude,
+			p.created_at,
+			p.edit_at,
+			p.visibility
+		FROM clusters c
+		LEFT JOIN pins p ON c.count = 1 AND p.id = c.any_id::uuid
+		ORDER BY c.count DESC, longitude, latitude
+	`, condition)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pin clusters: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cluster model.PinCluster
+		var (
+			id, name, pinUserID sql.NullString
+			pinLng, pinLat      sql.NullFloat64
+			createdAt, editedAt sql.NullTime
+			visibility          sql.NullString
+		)
+		err := rows.Scan(
+			&cluster.Count,
+			&cluster.Longitude,
+			&cluster.Latitude,
+			&id,
+			&name,
+			&pinUserID,
+			&pinLng,
+			&pinLat,
+			&createdAt,
+			&editedAt,
+			&visibility,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin cluster: %w", err)
+		}
+
+		// 単一のPinからなるクラスタは実際のPinに展開
+		if id.Valid {
+			cluster.Pin = &model.Pin{
+				ID:         id.String,
+				Name:       name.String,
+				UserID:     pinUserID.String,
+				Longitude:  pinLng.Float64,
+				Latitude:   pinLat.Float64,
+				CreatedAt:  createdAt.Time,
+				EditedAt:   editedAt.Time,
+				Visibility: visibility.String,
+			}
+		}
+		clusters = append(clusters, &cluster)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pin clusters: %w", err)
+	}
+
+	return clusters, nil
+}
+
+// FindTile は指定タイル座標のPinをMapbox Vector Tile形式で取得します
+// ST_TileEnvelopeでタイル範囲を作成し、ST_AsMVTGeom/ST_AsMVTでエンコード
+// viewerIDのユーザーが閲覧できるPin（公開範囲がpublicのPinと自分のPin）のみを含める
+// userIDが空でない場合はさらにそのユーザーのPinのみに絞り込む
+func (r *pinRepositoryImpl) FindTile(ctx context.Context, z, x, y int, viewerID, userID string) ([]byte, error) {
+	var tile []byte
+
+	query := `
+		WITH bounds AS (
+			SELECT ST_TileEnvelope($1, $2, $3) as geom
+		),
+		mvtgeom AS (
+			SELECT
+				ST_AsMVTGeom(ST_Transform(p.location, 3857), bounds.geom) as geom,
+				p.id::text as id,
+				p.name,
+				p.user_id::text as user_id
+			FROM pins p, bounds
+			WHERE p.deleted_at IS NULL
+				AND (p.visibility = 'public' OR p.user_id = $4)
+				AND ST_Intersects(p.location, ST_Transform(bounds.geom, 4326))
+				AND ($5::uuid IS NULL OR p.user_id = $5::uuid)
+		)
+		SELECT ST_AsMVT(mvtgeom.*, 'pins', 4096, 'geom')
+		FROM mvtgeom
+	`
+
+	filter := sql.NullString{String: userID, Valid: userID != ""}
+
+	err := r.db.QueryRowContext(ctx, query, z, x, y, viewerID, filter).Scan(&tile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pin tile: %w", err)
+	}
+
+	return tile, nil
+}
+
 // SoftDelete はPinを論理削除します
+// Pinに添付された写真も同じトランザクションで論理削除します
 // 要件: 7.1
 func (r *pinRepositoryImpl) SoftDelete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE pins
 		SET deleted_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete pin: %w", err)
 	}
@@ -200,5 +869,252 @@ func (r *pinRepositoryImpl) SoftDelete(ctx context.Context, id string) error {
 		return fmt.Errorf("pin not found or already deleted: %s", id)
 	}
 
+	photosQuery := `
+		UPDATE photos
+		SET deleted_at = NOW()
+		WHERE pin_id = $1 AND deleted_at IS NULL
+	`
+
+	if _, err := tx.ExecContext(ctx, photosQuery, id); err != nil {
+		return fmt.Errorf("failed to soft delete photos: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindDeletedByID はIDでゴミ箱にある（論理削除された）Pinを検索します
+func (r *pinRepositoryImpl) FindDeletedByID(ctx context.Context, id string) (*model.Pin, error) {
+	query := `
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	pin, err := scanPin(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("deleted pin not found with id: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find deleted pin by id: %w", err)
+	}
+
+	return pin, nil
+}
+
+// FindTrashPageByUserID はユーザーのゴミ箱にあるPinを (deleted_at, id) の降順に最大limit件検索します
+// afterが指定された場合はそのカーソル（削除日時とID）より後ろの行から取得します
+func (r *pinRepositoryImpl) FindTrashPageByUserID(ctx context.Context, userID string, after *model.PageCursor, limit int) ([]*model.Pin, error) {
+	var pins []*model.Pin
+
+	afterDeletedAt, afterID := cursorArgs(after)
+
+	query := `
+		SELECT ` + pinColumns + `
+		FROM pins ` + pinRatingsJoin + `
+		WHERE user_id = $1
+			AND deleted_at IS NOT NULL
+			AND ($2::timestamp IS NULL OR (deleted_at, id) < ($2::timestamp, $3::uuid))
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, afterDeletedAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted pins: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// Restore はゴミ箱にあるPinを元に戻します
+// SoftDeleteでPinと同時に削除された写真も同じトランザクションで元に戻します
+func (r *pinRepositoryImpl) Restore(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Pinの削除日時を取得して復元する
+	var deletedAt time.Time
+	query := `
+		UPDATE pins AS p
+		SET deleted_at = NULL
+		FROM (SELECT id, deleted_at FROM pins WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE) AS old
+		WHERE p.id = old.id
+		RETURNING old.deleted_at
+	`
+
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&deletedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("pin not found or not deleted: %s", id)
+		}
+		return fmt.Errorf("failed to restore pin: %w", err)
+	}
+
+	// Pinの削除時に削除された写真のみを復元する（それ以前に個別に削除された写真は除く）
+	photosQuery := `
+		UPDATE photos
+		SET deleted_at = NULL
+		WHERE pin_id = $1 AND deleted_at = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, photosQuery, id, deletedAt); err != nil {
+		return fmt.Errorf("failed to restore photos: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
+
+// HardDelete はゴミ箱にあるPinを物理削除し、ストレージから削除すべき写真のキーを返します
+// 写真、レビュー、編集履歴、Connectは外部キーのON DELETE CASCADEで削除されます
+func (r *pinRepositoryImpl) HardDelete(ctx context.Context, id string) ([]string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var photos []struct {
+		StorageKey   string `db:"storage_key"`
+		ThumbnailKey string `db:"thumbnail_key"`
+	}
+	photosQuery := `
+		SELECT storage_key, thumbnail_key
+		FROM photos
+		WHERE pin_id = $1
+	`
+	if err := tx.SelectContext(ctx, &photos, photosQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to find photos: %w", err)
+	}
+
+	query := `
+		DELETE FROM pins
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hard delete pin: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("pin not found or not deleted: %s", id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	keys := make([]string, 0, len(photos)*2)
+	for _, photo := range photos {
+		keys = append(keys, photo.StorageKey, photo.ThumbnailKey)
+	}
+
+	return keys, nil
+}
+
+// FindExpiredTrashIDs はゴミ箱に入ってからretention以上経過したPinのIDを最大limit件検索します
+func (r *pinRepositoryImpl) FindExpiredTrashIDs(ctx context.Context, retention time.Duration, limit int) ([]string, error) {
+	var ids []string
+
+	query := `
+		SELECT id
+		FROM pins
+		WHERE deleted_at IS NOT NULL
+			AND deleted_at < NOW() - $1 * INTERVAL '1 second'
+		ORDER BY deleted_at, id
+		LIMIT $2
+	`
+
+	err := r.db.SelectContext(ctx, &ids, query, retention.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired pins: %w", err)
+	}
+
+	return ids, nil
+}
+
+// boundsCondition はバウンディングボックスの検索条件とプレースホルダー引数を組み立てます
+// ST_MakeEnvelopeで範囲を作成し、日付変更線をまたぐ場合は東西2つの範囲のORとする
+func boundsCondition(bbox model.BoundingBox, args []interface{}) (string, []interface{}) {
+	envelopes := make([]string, 0, 2)
+	for _, b := range bbox.Split() {
+		n := len(args)
+		envelopes = append(envelopes, fmt.Sprintf(
+			"ST_Intersects(location, ST_MakeEnvelope($%d, $%d, $%d, $%d, 4326))",
+			n+1, n+2, n+3, n+4,
+		))
+		// ST_MakeEnvelope(xmin, ymin, xmax, ymax)の順序
+		args = append(args, b.MinLng, b.MinLat, b.MaxLng, b.MaxLat)
+	}
+	return "(" + strings.Join(envelopes, " OR ") + ")", args
+}
+
+// filterCondition は設備属性による絞り込み条件とプレースホルダー引数を組み立てます
+// 営業中かどうかはopening_hours形式をSQLで評価できないため、営業時間を持つPinへの絞り込みのみを行います
+// 条件が指定されていない場合はTRUEを返します
+func filterCondition(filter model.PinFilter, args []interface{}) (string, []interface{}) {
+	columns := []struct {
+		name  string
+		value *bool
+	}{
+		{"wheelchair_accessible", filter.WheelchairAccessible},
+		{"baby_changing", filter.BabyChanging},
+		{"gender_neutral", filter.GenderNeutral},
+		{"washlet", filter.Washlet},
+		{"paid", filter.Paid},
+	}
+
+	conditions := []string{"TRUE"}
+	for _, column := range columns {
+		if column.value == nil {
+			continue
+		}
+		args = append(args, *column.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column.name, len(args)))
+	}
+	if filter.OpenAt != nil {
+		conditions = append(conditions, "opening_hours IS NOT NULL")
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// cursorTimestampLayout はカーソルのcreated_atをTIMESTAMP型に渡すための書式
+const cursorTimestampLayout = "2006-01-02 15:04:05.999999"
+
+// cursorArgs はキーセットページネーションのカーソルをプレースホルダー引数に変換します
+// カーソルが無い場合はNULLを返し、クエリ側で条件を無効にします
+func cursorArgs(after *model.PageCursor) (sql.NullString, sql.NullString) {
+	if after == nil {
+		return sql.NullString{}, sql.NullString{}
+	}
+	return sql.NullString{String: after.CreatedAt.UTC().Format(cursorTimestampLayout), Valid: true},
+		sql.NullString{String: after.ID, Valid: true}
+}
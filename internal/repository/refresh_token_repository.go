@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// RefreshTokenRepository はリフレッシュトークンのデータアクセスのインターフェースを定義します
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *model.RefreshToken, ttl time.Duration) error
+	FindActiveByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	Rotate(ctx context.Context, currentID string, next *model.RefreshToken, ttl time.Duration) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// refreshTokenRepositoryImpl はRefreshTokenRepositoryの実装
+type refreshTokenRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewRefreshTokenRepository は新しいRefreshTokenRepositoryインスタンスを作成します
+func NewRefreshTokenRepository(db *sqlx.DB) RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// insertRefreshTokenQuery はリフレッシュトークンを作成するINSERT文
+// 有効期限はデータベースの時刻を基準にttl秒後とします
+const insertRefreshTokenQuery = `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, NOW() + $5 * INTERVAL '1 second')
+		RETURNING expires_at, created_at
+	`
+
+// Create は新しいリフレッシュトークンを作成します
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *model.RefreshToken, ttl time.Duration) error {
+	return insertRefreshToken(ctx, r.db, token, ttl)
+}
+
+// insertRefreshToken はリフレッシュトークンを作成します
+func insertRefreshToken(ctx context.Context, db sqlx.QueryerContext, token *model.RefreshToken, ttl time.Duration) error {
+	// UUIDを生成
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	err := db.QueryRowxContext(
+		ctx,
+		insertRefreshTokenQuery,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		ttl.Seconds(),
+	).Scan(&token.ExpiresAt, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// FindActiveByHash はハッシュで有効期限内のリフレッシュトークンを検索します
+// 交換済み・失効済みのトークンも返すため、呼び出し側で状態を確認してください
+func (r *refreshTokenRepositoryImpl) FindActiveByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, rotated_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1 AND expires_at > NOW()
+	`
+
+	err := r.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("refresh token not found")
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Rotate は現在のトークンを交換済みにし、同じ系列の次のトークンを1つのトランザクションで作成します
+// 現在のトークンが既に交換済み・失効済み・期限切れの場合は何もせずfalseを返します
+func (r *refreshTokenRepositoryImpl) Rotate(ctx context.Context, currentID string, next *model.RefreshToken, ttl time.Duration) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 同時に同じトークンが使用された場合でも、交換できるのは1回のみ
+	result, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens
+		SET rotated_at = NOW()
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+	`, currentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := insertRefreshToken(ctx, tx, next, ttl); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// RevokeFamily は同じ系列の全てのリフレッシュトークンを失効させます
+func (r *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeByUserID はユーザーの全てのリフレッシュトークンを失効させます
+func (r *refreshTokenRepositoryImpl) RevokeByUserID(ctx context.Context, userID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// ReviewRepository はレビューデータアクセスのインターフェースを定義します
+type ReviewRepository interface {
+	Create(ctx context.Context, review *model.Review) error
+	Update(ctx context.Context, review *model.Review) error
+	FindByID(ctx context.Context, id string) (*model.Review, error)
+	FindByPinAndUser(ctx context.Context, pinID, userID string) (*model.Review, error)
+	FindPageByPinID(ctx context.Context, pinID string, after *model.PageCursor, limit int) ([]*model.Review, error)
+	SoftDelete(ctx context.Context, id string) error
+}
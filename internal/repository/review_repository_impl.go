@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// reviewRepositoryImpl はReviewRepositoryの実装
+type reviewRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewReviewRepository は新しいReviewRepositoryインスタンスを作成します
+func NewReviewRepository(db *sqlx.DB) ReviewRepository {
+	return &reviewRepositoryImpl{
+		db: db,
+	}
+}
+
+// reviewColumns はレビューを取得するSELECT句の列
+const reviewColumns = `
+			id,
+			pin_id,
+			user_id,
+			cleanliness,
+			crowding,
+			paper_availability,
+			comment,
+			created_at,
+			edit_at,
+			deleted_at`
+
+// Create は新しいレビューをデータベースに作成します
+// 同じユーザーが同じPinに削除されていないレビューを既に持つ場合は一意制約違反のエラーになります
+func (r *reviewRepositoryImpl) Create(ctx context.Context, review *model.Review) error {
+	// UUIDを生成
+	if review.ID == "" {
+		review.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO reviews (id, pin_id, user_id, cleanliness, crowding, paper_availability, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, edit_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		review.ID,
+		review.PinID,
+		review.UserID,
+		review.Cleanliness,
+		review.Crowding,
+		review.PaperAvailability,
+		review.Comment,
+	).Scan(&review.ID, &review.CreatedAt, &review.EditedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return nil
+}
+
+// Update はレビューのスコアとコメントを更新します
+func (r *reviewRepositoryImpl) Update(ctx context.Context, review *model.Review) error {
+	query := `
+		UPDATE reviews
+		SET
+			cleanliness = $1,
+			crowding = $2,
+			paper_availability = $3,
+			comment = $4,
+			edit_at = NOW()
+		WHERE id = $5 AND deleted_at IS NULL
+		RETURNING edit_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		review.Cleanliness,
+		review.Crowding,
+		review.PaperAvailability,
+		review.Comment,
+		review.ID,
+	).Scan(&review.EditedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("review not found or already deleted: %s", review.ID)
+		}
+		return fmt.Errorf("failed to update review: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID はIDでレビューを検索します
+func (r *reviewRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Review, error) {
+	var review model.Review
+
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	err := r.db.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("review not found with id: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find review by id: %w", err)
+	}
+
+	return &review, nil
+}
+
+// FindByPinAndUser は指定されたユーザーのPinに対するレビューを検索します
+// レビューが存在しない場合はnilを返します
+func (r *reviewRepositoryImpl) FindByPinAndUser(ctx context.Context, pinID, userID string) (*model.Review, error) {
+	var review model.Review
+
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE pin_id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	err := r.db.GetContext(ctx, &review, query, pinID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find review by pin and user: %w", err)
+	}
+
+	return &review, nil
+}
+
+// FindPageByPinID はPinのレビューを (created_at, id) の降順に最大limit件検索します
+// afterが指定された場合はそのカーソルより後ろの行から取得するキーセットページネーションを行います
+func (r *reviewRepositoryImpl) FindPageByPinID(ctx context.Context, pinID string, after *model.PageCursor, limit int) ([]*model.Review, error) {
+	var reviews []*model.Review
+
+	afterCreatedAt, afterID := cursorArgs(after)
+
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE pin_id = $1
+			AND deleted_at IS NULL
+			AND ($2::timestamp IS NULL OR (created_at, id) < ($2::timestamp, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+
+	err := r.db.SelectContext(ctx, &reviews, query, pinID, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviews by pin id: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// SoftDelete はレビューを論理削除します
+func (r *reviewRepositoryImpl) SoftDelete(ctx context.Context, id string) error {
+	query := `
+		UPDATE reviews
+		SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete review: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("review not found or already deleted: %s", id)
+	}
+
+	return nil
+}
@@ -12,4 +12,6 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	FindByID(ctx context.Context, id string) (*model.User, error)
 	Update(ctx context.Context, user *model.User) error
+	MarkEmailVerified(ctx context.Context, id, email string) (bool, error)
+	SetVerificationMailFailed(ctx context.Context, id string, failed bool) error
 }
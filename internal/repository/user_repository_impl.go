@@ -58,7 +58,7 @@ func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*mo
 	var user model.User
 
 	query := `
-		SELECT id, name, email, password, created_at, updated_at, deleted_at
+		SELECT id, name, email, password, created_at, updated_at, deleted_at, email_verified_at, verification_mail_failed_at
 		FROM users
 		WHERE email = $1 AND deleted_at IS NULL
 	`
@@ -80,7 +80,7 @@ func (r *userRepositoryImpl) FindByID(ctx context.Context, id string) (*model.Us
 	var user model.User
 
 	query := `
-		SELECT id, name, email, password, created_at, updated_at, deleted_at
+		SELECT id, name, email, password, created_at, updated_at, deleted_at, email_verified_at, verification_mail_failed_at
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -124,3 +124,41 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *model.User) error
 
 	return nil
 }
+
+// MarkEmailVerified はメールアドレスを確認済みにします
+// メールアドレスが変更されている場合や既に確認済みの場合は更新せず、falseを返します
+func (r *userRepositoryImpl) MarkEmailVerified(ctx context.Context, id, email string) (bool, error) {
+	query := `
+		UPDATE users
+		SET email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND email = $2 AND email_verified_at IS NULL AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, email)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// SetVerificationMailFailed は確認メールの送信に失敗したかを記録します
+// failedがfalseの場合は記録を削除します
+func (r *userRepositoryImpl) SetVerificationMailFailed(ctx context.Context, id string, failed bool) error {
+	query := `
+		UPDATE users
+		SET verification_mail_failed_at = CASE WHEN $2::boolean THEN NOW() END
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, failed); err != nil {
+		return fmt.Errorf("failed to set verification mail failure: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore はメモリ上に失効状態を保存するStoreの実装
+// プロセスを再起動すると失効状態は失われるため、テストや単一プロセスでの開発に使用します
+type memoryStore struct {
+	mu          sync.Mutex
+	revoked     map[string]time.Time // jti → 失効記録を削除できる時刻
+	generations map[string]int       // ユーザーID → トークン世代
+	now         func() time.Time
+}
+
+// NewMemoryStore は新しいメモリ上のStoreを作成します
+func NewMemoryStore() Store {
+	return &memoryStore{
+		revoked:     make(map[string]time.Time),
+		generations: make(map[string]int),
+		now:         time.Now,
+	}
+}
+
+// Revoke はjtiのトークンを失効させます
+func (s *memoryStore) Revoke(ctx context.Context, jti, userID string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, expiresAt := range s.revoked {
+		if !expiresAt.After(now) {
+			delete(s.revoked, id)
+		}
+	}
+	s.revoked[jti] = now.Add(ttl)
+
+	return nil
+}
+
+// IsRevoked はトークンが失効しているかを返します
+func (s *memoryStore) IsRevoked(ctx context.Context, jti, userID string, generation int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expiresAt, ok := s.revoked[jti]; ok && expiresAt.After(s.now()) {
+		return true, nil
+	}
+
+	return generation < s.generations[userID], nil
+}
+
+// Generation はユーザーの現在のトークン世代を返します
+func (s *memoryStore) Generation(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.generations[userID], nil
+}
+
+// RevokeAll はユーザーのトークン世代を進めます
+func (s *memoryStore) RevokeAll(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generations[userID]++
+
+	return nil
+}
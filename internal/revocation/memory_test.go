@@ -0,0 +1,74 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Revoke(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	revoked, err := store.IsRevoked(ctx, "jti-1", "user-1", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, store.Revoke(ctx, "jti-1", "user-1", time.Minute))
+
+	revoked, err = store.IsRevoked(ctx, "jti-1", "user-1", 0)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// 他のトークンは影響を受けない
+	revoked, err = store.IsRevoked(ctx, "jti-2", "user-1", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestMemoryStore_RevokeExpires(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore().(*memoryStore)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Revoke(ctx, "jti-1", "user-1", time.Minute))
+
+	// トークンの有効期限を過ぎた失効記録は次の失効時に削除される
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, store.Revoke(ctx, "jti-2", "user-1", time.Minute))
+	assert.NotContains(t, store.revoked, "jti-1")
+	assert.Contains(t, store.revoked, "jti-2")
+}
+
+func TestMemoryStore_RevokeAll(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	generation, err := store.Generation(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, generation)
+
+	require.NoError(t, store.RevokeAll(ctx, "user-1"))
+
+	// 以前の世代のトークンは全て失効する
+	revoked, err := store.IsRevoked(ctx, "jti-1", "user-1", 0)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// 新しい世代で発行したトークンは有効
+	generation, err = store.Generation(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, generation)
+	revoked, err = store.IsRevoked(ctx, "jti-2", "user-1", generation)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// 他のユーザーは影響を受けない
+	revoked, err = store.IsRevoked(ctx, "jti-3", "user-2", 0)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
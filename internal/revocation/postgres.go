@@ -0,0 +1,86 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// postgresStore はPostgreSQLに失効状態を保存するStoreの実装
+// 失効したjtiはrevoked_tokensテーブル、トークン世代はusers.token_generationに保存します
+type postgresStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresStore は新しいPostgreSQLのStoreを作成します
+func NewPostgresStore(db *sqlx.DB) Store {
+	return &postgresStore{
+		db: db,
+	}
+}
+
+// Revoke はjtiのトークンを失効させます
+// 有効期限を過ぎた失効記録は不要なため、同時に削除します
+func (s *postgresStore) Revoke(ctx context.Context, jti, userID string, ttl time.Duration) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, jti, userID, ttl.Seconds()); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired revocations: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked はトークンが失効しているかを返します
+func (s *postgresStore) IsRevoked(ctx context.Context, jti, userID string, generation int) (bool, error) {
+	var revoked bool
+
+	query := `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1 AND expires_at > NOW())
+			OR COALESCE((SELECT token_generation FROM users WHERE id = $2::uuid), 0) > $3::integer
+	`
+
+	if err := s.db.GetContext(ctx, &revoked, query, jti, userID, generation); err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// Generation はユーザーの現在のトークン世代を返します
+func (s *postgresStore) Generation(ctx context.Context, userID string) (int, error) {
+	var generation int
+
+	query := `SELECT token_generation FROM users WHERE id = $1`
+
+	if err := s.db.GetContext(ctx, &generation, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to get token generation: %w", err)
+	}
+
+	return generation, nil
+}
+
+// RevokeAll はユーザーのトークン世代を進めます
+func (s *postgresStore) RevokeAll(ctx context.Context, userID string) error {
+	query := `
+		UPDATE users
+		SET token_generation = token_generation + 1
+		WHERE id = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to revoke all tokens: %w", err)
+	}
+
+	return nil
+}
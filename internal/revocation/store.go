@@ -0,0 +1,23 @@
+// Package revocation はログアウトなどで無効にしたアクセストークンの失効状態を管理します
+//
+// トークンはjti（トークンID）ごとに失効させるほか、ユーザーごとのトークン世代を進めることで
+// それより前の世代で発行された全てのトークンをまとめて失効させることができます
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+// Store はアクセストークンの失効状態を保存するストアのインターフェースを定義します
+type Store interface {
+	// Revoke はjtiのトークンを失効させます。ttlはトークンの残りの有効期間で、経過後は記録を削除できます
+	Revoke(ctx context.Context, jti, userID string, ttl time.Duration) error
+	// IsRevoked はトークンが失効しているかを返します
+	// jtiが失効済み、またはgenerationがユーザーの現在のトークン世代より古い場合に失効とみなします
+	IsRevoked(ctx context.Context, jti, userID string, generation int) (bool, error)
+	// Generation はユーザーの現在のトークン世代を返します。トークンの発行時に使用します
+	Generation(ctx context.Context, userID string) (int, error)
+	// RevokeAll はユーザーのトークン世代を進め、発行済みの全てのトークンを失効させます
+	RevokeAll(ctx context.Context, userID string) error
+}
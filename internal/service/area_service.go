@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+)
+
+var (
+	// ErrAreaNotFound はAreaが見つからないエラー
+	ErrAreaNotFound = errors.New("area not found")
+	// ErrUnauthorizedAreaAccess はAreaへの不正アクセスエラー
+	ErrUnauthorizedAreaAccess = errors.New("unauthorized access to area")
+	// ErrInvalidAreaPins はAreaを構成するPinの数が範囲外、または同じPinが重複しているエラー
+	ErrInvalidAreaPins = errors.New("invalid area pins")
+	// ErrInvalidAreaRing はPinの環が辺の交差する、または面積が0のポリゴンになるエラー
+	ErrInvalidAreaRing = errors.New("area ring is not a simple polygon")
+	// ErrConnectsNotCycle は指定されたConnectが1つの閉路になっていないエラー（model.ErrNotCycleと同じ）
+	ErrConnectsNotCycle = model.ErrNotCycle
+)
+
+// AreaService はArea関連のビジネスロジックを提供します
+type AreaService interface {
+	CreateArea(ctx context.Context, userID, name string, pinIDs []string) (*model.Area, error)
+	CreateAreaFromConnects(ctx context.Context, userID, name string, connectIDs []string) (*model.Area, error)
+	GetArea(ctx context.Context, areaID, userID string) (*model.Area, error)
+	GetAreaPageByUser(ctx context.Context, userID, cursor string, limit int) (*model.AreaPage, error)
+	GetAreasContainingPin(ctx context.Context, userID, pinID string) ([]*model.Area, error)
+	GetAreasContainingPoint(ctx context.Context, userID string, lat, lng float64) ([]*model.Area, error)
+	DeleteArea(ctx context.Context, areaID, userID string) error
+}
+
+// areaServiceImpl はAreaServiceの実装
+type areaServiceImpl struct {
+	areaRepo    repository.AreaRepository
+	pinRepo     repository.PinRepository
+	connectRepo repository.ConnectRepository
+}
+
+// NewAreaService は新しいAreaServiceインスタンスを作成します
+func NewAreaService(areaRepo repository.AreaRepository, pinRepo repository.PinRepository, connectRepo repository.ConnectRepository) AreaService {
+	return &areaServiceImpl{
+		areaRepo:    areaRepo,
+		pinRepo:     pinRepo,
+		connectRepo: connectRepo,
+	}
+}
+
+// CreateArea は順序付きのPinの環で囲まれたAreaを作成します
+// 環に使用できるのは自分のPinのみで、環は辺が交差しない多角形になる必要があります
+func (s *areaServiceImpl) CreateArea(ctx context.Context, userID, name string, pinIDs []string) (*model.Area, error) {
+	if len(pinIDs) < model.MinAreaPins || len(pinIDs) > model.MaxAreaPins {
+		return nil, ErrInvalidAreaPins
+	}
+
+	// 大文字・小文字の違いなど表記の異なる同じIDも重複として扱う
+	ids := make([]uuid.UUID, 0, len(pinIDs))
+	seen := make(map[uuid.UUID]bool, len(pinIDs))
+	for _, id := range pinIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil || seen[parsed] {
+			return nil, ErrInvalidAreaPins
+		}
+		seen[parsed] = true
+		ids = append(ids, parsed)
+	}
+
+	// 表記を揃えて保存する
+	pinIDs = make([]string, 0, len(ids))
+	for _, id := range ids {
+		pinIDs = append(pinIDs, id.String())
+	}
+
+	// Pinの存在と所有権の確認
+	pins, err := s.pinRepo.FindByIDs(ctx, pinIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+	byID := make(map[uuid.UUID]*model.Pin, len(pins))
+	for _, pin := range pins {
+		if id, err := uuid.Parse(pin.ID); err == nil {
+			byID[id] = pin
+		}
+	}
+
+	ring := make([]model.LatLng, 0, len(ids))
+	for _, id := range ids {
+		pin, ok := byID[id]
+		if !ok {
+			return nil, ErrPinNotExist
+		}
+		if err := checkOwnPin(pin, userID); err != nil {
+			return nil, err
+		}
+		ring = append(ring, model.LatLng{Latitude: pin.Latitude, Longitude: pin.Longitude})
+	}
+
+	if !model.IsSimpleRing(ring) {
+		return nil, ErrInvalidAreaRing
+	}
+
+	area := &model.Area{
+		UserID: userID,
+		Name:   name,
+		PinIDs: pinIDs,
+	}
+
+	if err := s.areaRepo.Create(ctx, area, ring); err != nil {
+		return nil, fmt.Errorf("failed to create area: %w", err)
+	}
+
+	// 面積と重心を含めて返す
+	created, err := s.areaRepo.FindByID(ctx, area.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created area: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateAreaFromConnects は1つの閉路になっているConnectからAreaを作成します
+// Connectは順不同で指定でき、閉路を辿った順序のPinの環でAreaを作成します
+func (s *areaServiceImpl) CreateAreaFromConnects(ctx context.Context, userID, name string, connectIDs []string) (*model.Area, error) {
+	if len(connectIDs) > model.MaxAreaPins {
+		return nil, ErrInvalidAreaPins
+	}
+
+	ids := make([]uuid.UUID, 0, len(connectIDs))
+	for _, id := range connectIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, ErrConnectNotFound
+		}
+		ids = append(ids, parsed)
+	}
+
+	// Connectの存在と所有権の確認
+	connects, err := s.connectRepo.FindByIDs(ctx, connectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connects: %w", err)
+	}
+	byID := make(map[uuid.UUID]*model.ConnectLine, len(connects))
+	for _, connect := range connects {
+		if id, err := uuid.Parse(connect.ID); err == nil {
+			byID[id] = connect
+		}
+	}
+
+	edges := make([]model.Edge, 0, len(ids))
+	for _, id := range ids {
+		connect, ok := byID[id]
+		if !ok {
+			return nil, ErrConnectNotFound
+		}
+		if connect.UserID != userID {
+			return nil, ErrUnauthorizedConnectAccess
+		}
+		edges = append(edges, model.Edge{From: connect.PinID1, To: connect.PinID2})
+	}
+
+	pinIDs, err := model.OrderRing(edges)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateArea(ctx, userID, name, pinIDs)
+}
+
+// GetArea はAreaを取得します（自分のAreaのみ）
+func (s *areaServiceImpl) GetArea(ctx context.Context, areaID, userID string) (*model.Area, error) {
+	area, err := s.areaRepo.FindByID(ctx, areaID)
+	if err != nil {
+		return nil, ErrAreaNotFound
+	}
+
+	// 所有権の確認
+	if area.UserID != userID {
+		return nil, ErrUnauthorizedAreaAccess
+	}
+
+	return area, nil
+}
+
+// GetAreaPageByUser は指定されたユーザーのAreaを新しい順に1ページ分取得します
+// limit+1件を取得し、次のページが存在する場合のみNextCursorを設定します
+func (s *areaServiceImpl) GetAreaPageByUser(ctx context.Context, userID, cursor string, limit int) (*model.AreaPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	areas, err := s.areaRepo.FindPageByUserID(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get areas: %w", err)
+	}
+
+	page := &model.AreaPage{}
+	page.Items, page.NextCursor = newPage(areas, limit, func(area *model.Area) string {
+		return model.NewPageCursor(area.CreatedAt, area.ID).Encode()
+	})
+
+	return page, nil
+}
+
+// GetAreasContainingPin は自分のAreaのうちPinの位置を含むものを取得します
+// 閲覧できないPinは存在しないものとして扱います
+func (s *areaServiceImpl) GetAreasContainingPin(ctx context.Context, userID, pinID string) ([]*model.Area, error) {
+	pin, err := findVisiblePin(ctx, s.pinRepo, pinID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAreasContainingPoint(ctx, userID, pin.Latitude, pin.Longitude)
+}
+
+// GetAreasContainingPoint は自分のAreaのうち指定した地点を含むものを取得します
+func (s *areaServiceImpl) GetAreasContainingPoint(ctx context.Context, userID string, lat, lng float64) ([]*model.Area, error) {
+	if !isValidCoordinates(lat, lng) {
+		return nil, ErrInvalidCoordinates
+	}
+
+	areas, err := s.areaRepo.FindContaining(ctx, userID, lat, lng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get areas: %w", err)
+	}
+
+	// 結果が空の場合は空のスライスを返す
+	if areas == nil {
+		areas = []*model.Area{}
+	}
+
+	return areas, nil
+}
+
+// DeleteArea はAreaを削除します（自分のAreaのみ）
+func (s *areaServiceImpl) DeleteArea(ctx context.Context, areaID, userID string) error {
+	if _, err := s.GetArea(ctx, areaID, userID); err != nil {
+		return err
+	}
+
+	if err := s.areaRepo.Delete(ctx, areaID); err != nil {
+		return fmt.Errorf("failed to delete area: %w", err)
+	}
+
+	return nil
+}
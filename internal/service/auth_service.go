@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 )
 
@@ -17,25 +20,45 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	// ErrUserNotFound はユーザーが見つからないエラー
 	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidRefreshToken はリフレッシュトークンが無効・期限切れ・失効済みのエラー
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused は交換済みのリフレッシュトークンが再使用されたエラー
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrMissingTokenID はアクセストークンにjtiがなく失効できないエラー
+	ErrMissingTokenID = errors.New("token has no jti")
 )
 
+// RefreshTokenTTL はリフレッシュトークンの有効期間
+// トークンを交換するたびに新しいトークンの有効期間が始まります
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
 // AuthService は認証関連のビジネスロジックを提供します
 type AuthService interface {
 	SignUp(ctx context.Context, email, password, name string) (*model.User, error)
-	Login(ctx context.Context, email, password string) (string, *model.User, error)
+	Login(ctx context.Context, email, password string) (*model.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.AuthResponse, error)
+	Logout(ctx context.Context, claims *util.JWTClaims, refreshToken string) error
+	LogoutAll(ctx context.Context, userID string) error
 	ValidateToken(ctx context.Context, token string) (*model.User, error)
 	TestConnection(ctx context.Context) error
 }
 
 // authServiceImpl はAuthServiceの実装
 type authServiceImpl struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	revocationStore  revocation.Store
+	keySet           *util.KeySet
 }
 
 // NewAuthService は新しいAuthServiceインスタンスを作成します
-func NewAuthService(userRepo repository.UserRepository) AuthService {
+// revocationStoreとkeySetはmiddleware.NewAuthMiddlewareと同じものを指定してください
+func NewAuthService(userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, revocationStore revocation.Store, keySet *util.KeySet) AuthService {
 	return &authServiceImpl{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+		keySet:           keySet,
 	}
 }
 
@@ -74,34 +97,158 @@ func (s *authServiceImpl) SignUp(ctx context.Context, email, password, name stri
 }
 
 // Login はユーザーのログイン処理を行います
+// アクセストークンと、新しい系列のリフレッシュトークンを発行します
 // 要件: 2.1, 2.2, 2.3, 2.4
-func (s *authServiceImpl) Login(ctx context.Context, email, password string) (string, *model.User, error) {
+func (s *authServiceImpl) Login(ctx context.Context, email, password string) (*model.AuthResponse, error) {
 	// ユーザーの検索（要件: 2.1）
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return "", nil, ErrInvalidCredentials
+		return nil, ErrInvalidCredentials
 	}
 
 	// パスワードの検証（要件: 2.1）
 	if err := util.CheckPassword(password, user.Password); err != nil {
-		return "", nil, ErrInvalidCredentials
+		return nil, ErrInvalidCredentials
 	}
 
-	// JWTトークンの生成（要件: 2.2）
-	token, err := util.GenerateToken(user.ID, user.Email)
+	// リフレッシュトークンの発行
+	refreshToken, record, err := newRefreshToken(user.ID, uuid.New().String())
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Create(ctx, record, RefreshTokenTTL); err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
 	// 要件: 2.3 - 認証トークンとユーザー情報を返す
-	return token, user, nil
+	return s.newAuthResponse(ctx, user, refreshToken)
+}
+
+// Refresh はリフレッシュトークンを新しいトークンに交換し、アクセストークンを再発行します
+// 交換済みのトークンが再び使用された場合は、トークンが盗まれたとみなして同じ系列のトークンを全て失効させます
+func (s *authServiceImpl) Refresh(ctx context.Context, refreshToken string) (*model.AuthResponse, error) {
+	current, err := s.refreshTokenRepo.FindActiveByHash(ctx, util.HashToken(refreshToken))
+	if err != nil || current.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if current.RotatedAt != nil {
+		return nil, s.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, current.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 同じ系列の次のトークンに交換
+	nextToken, next, err := newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.refreshTokenRepo.Rotate(ctx, current.ID, next, RefreshTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		// 検索してから交換するまでの間に同じトークンが使用された
+		return nil, s.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	return s.newAuthResponse(ctx, user, nextToken)
+}
+
+// revokeReusedFamily は再使用されたリフレッシュトークンの系列を全て失効させます
+func (s *authServiceImpl) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// newRefreshToken は新しいリフレッシュトークンと、保存用のレコードを生成します
+func newRefreshToken(userID, familyID string) (string, *model.RefreshToken, error) {
+	token, err := util.GenerateRandomToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return token, &model.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: util.HashToken(token),
+	}, nil
+}
+
+// newAuthResponse はユーザーの現在のトークン世代でアクセストークンを発行し、リフレッシュトークンと共に認証レスポンスを作成します
+func (s *authServiceImpl) newAuthResponse(ctx context.Context, user *model.User, refreshToken string) (*model.AuthResponse, error) {
+	generation, err := s.revocationStore.Generation(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token generation: %w", err)
+	}
+
+	// JWTトークンの生成（要件: 2.2）
+	token, err := s.keySet.GenerateToken(user.ID, user.Email, generation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &model.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(util.AccessTokenTTL.Seconds()),
+		User:         user,
+	}, nil
+}
+
+// Logout はアクセストークンを失効させます
+// refreshTokenが指定された場合は、同じログインから発行されたリフレッシュトークンも全て失効させます
+// 要件: 3.1
+func (s *authServiceImpl) Logout(ctx context.Context, claims *util.JWTClaims, refreshToken string) error {
+	if claims.ID == "" {
+		return ErrMissingTokenID
+	}
+
+	// 失効の記録はトークンの有効期限まで保持すればよい
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if err := s.revocationStore.Revoke(ctx, claims.ID, claims.UserID, ttl); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	// 他のユーザーのリフレッシュトークンは失効させない
+	current, err := s.refreshTokenRepo.FindActiveByHash(ctx, util.HashToken(refreshToken))
+	if err != nil || current.UserID != claims.UserID {
+		return nil
+	}
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// LogoutAll はユーザーの全端末からログアウトします
+// トークン世代を進めて発行済みのアクセストークンを全て失効させ、リフレッシュトークンも全て失効させます
+func (s *authServiceImpl) LogoutAll(ctx context.Context, userID string) error {
+	if err := s.revocationStore.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke all tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
 }
 
 // ValidateToken はJWTトークンを検証し、ユーザー情報を返します
 // 要件: 4.3, 5.1, 5.2, 5.3
 func (s *authServiceImpl) ValidateToken(ctx context.Context, token string) (*model.User, error) {
 	// トークンの検証
-	claims, err := util.ValidateToken(token)
+	claims, err := s.keySet.ValidateToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
@@ -139,6 +286,12 @@ func isUniqueViolation(err error) bool {
 		contains(err.Error(), "23505"))
 }
 
+// isCheckViolation はエラーが指定した名前のCHECK制約違反かどうかを判定します
+func isCheckViolation(err error, constraint string) bool {
+	// PostgreSQLのCHECK制約違反エラーコード: 23514
+	return err != nil && contains(err.Error(), "check constraint") && contains(err.Error(), constraint)
+}
+
 // isNotFoundError はエラーがレコード未検出エラーかどうかを判定します
 func isNotFoundError(err error) bool {
 	return err != nil && contains(err.Error(), "not found")
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// backgroundTaskTimeout はリクエストとは別に実行する処理のタイムアウト
+const backgroundTaskTimeout = 30 * time.Second
+
+// BackgroundTasks はリクエストの処理とは別に実行する処理（メール送信など）を管理します
+// サーバーの停止時はWaitで実行中の処理の完了を待ってください
+type BackgroundTasks struct {
+	wg sync.WaitGroup
+}
+
+// NewBackgroundTasks は新しいBackgroundTasksインスタンスを作成します
+func NewBackgroundTasks() *BackgroundTasks {
+	return &BackgroundTasks{}
+}
+
+// Go はリクエストのコンテキストから切り離したコンテキストでfnを実行します
+// fnがエラーを返した場合はnameを付けてログに出力します
+func (b *BackgroundTasks) Go(name string, fn func(ctx context.Context) error) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundTaskTimeout)
+		defer cancel()
+
+		if err := fn(ctx); err != nil {
+			log.Printf("Failed to %s: %v", name, err)
+		}
+	}()
+}
+
+// Wait は実行中の全ての処理の完了を待ちます
+func (b *BackgroundTasks) Wait() {
+	b.wg.Wait()
+}
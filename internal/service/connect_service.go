@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/graph"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
 )
@@ -18,13 +20,29 @@ var (
 	ErrPinNotExist = errors.New("specified pin does not exist")
 	// ErrInvalidPinIDs は無効なPin IDエラー
 	ErrInvalidPinIDs = errors.New("invalid pin IDs")
+	// ErrSelfConnect は同じPin同士を接続しようとしたエラー
+	ErrSelfConnect = errors.New("cannot connect a pin to itself")
+	// ErrDuplicateConnect は同じPinの組み合わせのConnectが既に存在するエラー
+	ErrDuplicateConnect = errors.New("connect already exists")
+	// ErrPinNotOwned は他のユーザーのPinを接続しようとしたエラー
+	ErrPinNotOwned = errors.New("pin is not owned by user")
+	// ErrNoPath はConnectを辿ってPin間を移動できないエラー
+	ErrNoPath = errors.New("no path between pins")
 )
 
+// connectDistinctPinsConstraint は同じPin同士のConnectを禁止するCHECK制約の名前
+const connectDistinctPinsConstraint = "connect_distinct_pins"
+
 // ConnectService はConnect関連のビジネスロジックを提供します
 type ConnectService interface {
-	CreateConnect(ctx context.Context, userID, pinID1, pinID2 string, show bool) (*model.Connect, error)
-	UpdateConnect(ctx context.Context, connectID, userID, pinID1, pinID2 string, show bool) (*model.Connect, error)
-	GetConnectsByUser(ctx context.Context, userID string) ([]*model.Connect, error)
+	CreateConnect(ctx context.Context, userID, pinID1, pinID2 string, show bool) (*model.ConnectLine, error)
+	UpdateConnect(ctx context.Context, connectID, userID, pinID1, pinID2 string, show bool) (*model.ConnectLine, error)
+	GetConnectsByUser(ctx context.Context, userID string) ([]*model.ConnectLine, error)
+	GetConnectPageByUser(ctx context.Context, userID, cursor string, limit int) (*model.ConnectPage, error)
+	ExpandConnectPins(ctx context.Context, userID string, lines []*model.ConnectLine) error
+	FindPath(ctx context.Context, userID, fromPinID, toPinID string) (*model.ConnectPath, error)
+	GetConnectGraph(ctx context.Context, userID string) (*model.ConnectGraph, error)
+	EachConnectLineByUser(ctx context.Context, userID string, fn func(*model.ConnectLine) error) error
 	DeleteConnect(ctx context.Context, connectID, userID string) error
 }
 
@@ -44,22 +62,28 @@ func NewConnectService(connectRepo repository.ConnectRepository, pinRepo reposit
 
 // CreateConnect は新しいConnectを作成します
 // 要件: 8.1, 8.2, 8.3, 8.4, 8.5, 8.6, 8.7
-func (s *connectServiceImpl) CreateConnect(ctx context.Context, userID, pinID1, pinID2 string, show bool) (*model.Connect, error) {
+func (s *connectServiceImpl) CreateConnect(ctx context.Context, userID, pinID1, pinID2 string, show bool) (*model.ConnectLine, error) {
 	// Pin IDの検証
 	if pinID1 == "" || pinID2 == "" {
 		return nil, ErrInvalidPinIDs
 	}
 
-	// Pin1の存在確認（要件: 8.6）
-	pin1, err := s.pinRepo.FindByID(ctx, pinID1)
-	if err != nil || pin1 == nil {
-		return nil, ErrPinNotExist
+	// 同じPin同士は接続できない
+	if samePin(pinID1, pinID2) {
+		return nil, ErrSelfConnect
+	}
+
+	// Pinの存在と所有権の確認（要件: 8.6）
+	if err := s.checkConnectablePin(ctx, userID, pinID1); err != nil {
+		return nil, err
+	}
+	if err := s.checkConnectablePin(ctx, userID, pinID2); err != nil {
+		return nil, err
 	}
 
-	// Pin2の存在確認（要件: 8.6）
-	pin2, err := s.pinRepo.FindByID(ctx, pinID2)
-	if err != nil || pin2 == nil {
-		return nil, ErrPinNotExist
+	// 同じ組み合わせのConnectの確認（Pinの順序は問わない）
+	if err := s.checkDuplicateConnect(ctx, userID, pinID1, pinID2, ""); err != nil {
+		return nil, err
 	}
 
 	// 新しいConnectの作成（要件: 8.1, 8.2, 8.3, 8.4, 8.5）
@@ -71,16 +95,23 @@ func (s *connectServiceImpl) CreateConnect(ctx context.Context, userID, pinID1,
 	}
 
 	if err := s.connectRepo.Create(ctx, connect); err != nil {
+		// 同時に作成された場合はデータベースの一意制約違反になる
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateConnect
+		}
+		if isCheckViolation(err, connectDistinctPinsConstraint) {
+			return nil, ErrSelfConnect
+		}
 		return nil, fmt.Errorf("failed to create connect: %w", err)
 	}
 
-	// 要件: 8.7 - 作成されたConnect情報を返す
-	return connect, nil
+	// 要件: 8.7 - 作成されたConnect情報を両端のPinの座標付きで返す
+	return s.findConnectLine(ctx, connect.ID)
 }
 
 // UpdateConnect は既存のConnectを更新します
 // 要件: 9.1, 9.2, 9.3, 9.4, 9.5, 9.6
-func (s *connectServiceImpl) UpdateConnect(ctx context.Context, connectID, userID, pinID1, pinID2 string, show bool) (*model.Connect, error) {
+func (s *connectServiceImpl) UpdateConnect(ctx context.Context, connectID, userID, pinID1, pinID2 string, show bool) (*model.ConnectLine, error) {
 	// 既存のConnectを取得（要件: 9.1）
 	connect, err := s.connectRepo.FindByID(ctx, connectID)
 	if err != nil {
@@ -92,38 +123,55 @@ func (s *connectServiceImpl) UpdateConnect(ctx context.Context, connectID, userI
 		return nil, ErrUnauthorizedConnectAccess
 	}
 
-	// Pin IDが指定されている場合は存在確認（要件: 9.5）
+	// Pin IDが指定されている場合は存在と所有権の確認（要件: 9.5）
+	pinsChanged := false
 	if pinID1 != "" {
-		pin1, err := s.pinRepo.FindByID(ctx, pinID1)
-		if err != nil || pin1 == nil {
-			return nil, ErrPinNotExist
+		if err := s.checkConnectablePin(ctx, userID, pinID1); err != nil {
+			return nil, err
 		}
+		pinsChanged = pinsChanged || pinID1 != connect.PinID1
 		connect.PinID1 = pinID1
 	}
 
 	if pinID2 != "" {
-		pin2, err := s.pinRepo.FindByID(ctx, pinID2)
-		if err != nil || pin2 == nil {
-			return nil, ErrPinNotExist
+		if err := s.checkConnectablePin(ctx, userID, pinID2); err != nil {
+			return nil, err
 		}
+		pinsChanged = pinsChanged || pinID2 != connect.PinID2
 		connect.PinID2 = pinID2
 	}
 
+	if pinsChanged {
+		if samePin(connect.PinID1, connect.PinID2) {
+			return nil, ErrSelfConnect
+		}
+		if err := s.checkDuplicateConnect(ctx, userID, connect.PinID1, connect.PinID2, connect.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	// showフィールドの更新（要件: 9.2, 9.3）
 	connect.Show = show
 
 	// Connectの更新（要件: 9.1）
-	if err := s.connectRepo.Update(ctx, connect); err != nil {
+	if err := s.connectRepo.Update(ctx, connect.Connect); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateConnect
+		}
+		if isCheckViolation(err, connectDistinctPinsConstraint) {
+			return nil, ErrSelfConnect
+		}
 		return nil, fmt.Errorf("failed to update connect: %w", err)
 	}
 
-	// 要件: 9.6 - 更新されたConnect情報を返す
-	return connect, nil
+	// 要件: 9.6 - 更新されたConnect情報を両端のPinの座標付きで返す
+	return s.findConnectLine(ctx, connect.ID)
 }
 
-// GetConnectsByUser は指定されたユーザーの全Connectを取得します
+// GetConnectsByUser は指定されたユーザーの全Connectを両端のPinの座標付きで取得します
+// 削除済みのPinや閲覧できないPinを参照しているConnectは除外します
 // 要件: 8.1, 9.1
-func (s *connectServiceImpl) GetConnectsByUser(ctx context.Context, userID string) ([]*model.Connect, error) {
+func (s *connectServiceImpl) GetConnectsByUser(ctx context.Context, userID string) ([]*model.ConnectLine, error) {
 	connects, err := s.connectRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connects: %w", err)
@@ -131,12 +179,196 @@ func (s *connectServiceImpl) GetConnectsByUser(ctx context.Context, userID strin
 
 	// 結果が空の場合は空のスライスを返す
 	if connects == nil {
-		connects = []*model.Connect{}
+		connects = []*model.ConnectLine{}
 	}
 
 	return connects, nil
 }
 
+// GetConnectPageByUser は指定されたユーザーのConnectを新しい順に1ページ分取得します
+// limit+1件を取得し、次のページが存在する場合のみNextCursorを設定します
+func (s *connectServiceImpl) GetConnectPageByUser(ctx context.Context, userID, cursor string, limit int) (*model.ConnectPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	connects, err := s.connectRepo.FindPageByUserID(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connects: %w", err)
+	}
+
+	page := &model.ConnectPage{}
+	page.Items, page.NextCursor = newPage(connects, limit, func(connect *model.ConnectLine) string {
+		return model.NewPageCursor(connect.CreatedAt, connect.ID).Encode()
+	})
+
+	return page, nil
+}
+
+// ExpandConnectPins はConnectの両端のPinをまとめて取得してPin1とPin2に設定します
+// ユーザーが閲覧できないPin（他のユーザーの非公開のPin）は設定しません
+func (s *connectServiceImpl) ExpandConnectPins(ctx context.Context, userID string, lines []*model.ConnectLine) error {
+	ids := make([]string, 0, len(lines)*2)
+	seen := make(map[string]bool, len(lines)*2)
+	for _, line := range lines {
+		for _, id := range []string{line.PinID1, line.PinID2} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	pins, err := s.pinRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get connect pins: %w", err)
+	}
+
+	byID := make(map[string]*model.Pin, len(pins))
+	for _, pin := range pins {
+		if pin.UserID == userID || pin.Visibility == model.PinVisibilityPublic {
+			byID[pin.ID] = pin
+		}
+	}
+
+	for _, line := range lines {
+		line.Pin1 = byID[line.PinID1]
+		line.Pin2 = byID[line.PinID2]
+	}
+
+	return nil
+}
+
+// FindPath はユーザーの表示中（show=true）のConnectを辿ってPin間の最短経路を探索します
+// 辺の重みはConnectの両端のPin間の測地線距離です
+// 経路が存在しない場合はErrNoPathを返します
+func (s *connectServiceImpl) FindPath(ctx context.Context, userID, fromPinID, toPinID string) (*model.ConnectPath, error) {
+	if fromPinID == "" || toPinID == "" {
+		return nil, ErrInvalidPinIDs
+	}
+
+	// Pinの存在確認（閲覧できないPinは存在しないものとして扱う）
+	for _, id := range []string{fromPinID, toPinID} {
+		if _, err := findVisiblePin(ctx, s.pinRepo, id, userID); err != nil {
+			return nil, ErrPinNotExist
+		}
+	}
+
+	lines, err := s.connectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connects: %w", err)
+	}
+
+	g := graph.New()
+	g.AddNode(fromPinID)
+	g.AddNode(toPinID)
+	for _, line := range lines {
+		if line.Show {
+			g.AddEdge(line.ID, line.PinID1, line.PinID2, line.DistanceMeters)
+		}
+	}
+
+	path, ok := g.ShortestPath(fromPinID, toPinID)
+	if !ok {
+		return nil, ErrNoPath
+	}
+
+	// 経路上のPinを順序通りに並べる
+	pins, err := s.pinRepo.FindByIDs(ctx, path.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get path pins: %w", err)
+	}
+	byID := make(map[string]*model.Pin, len(pins))
+	for _, pin := range pins {
+		byID[pin.ID] = pin
+	}
+
+	result := &model.ConnectPath{
+		Pins:           make([]*model.Pin, 0, len(path.Nodes)),
+		ConnectIDs:     path.Edges,
+		DistanceMeters: path.Weight,
+	}
+	for _, id := range path.Nodes {
+		pin, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("path pin not found: %s", id)
+		}
+		result.Pins = append(result.Pins, pin)
+	}
+
+	return result, nil
+}
+
+// GetConnectGraph はユーザーのPinを頂点、Connectを辺とするネットワークを取得します
+// 連結成分、Pinごとの次数、孤立したPin、閉路を含めて返します。show=falseのConnectも辺として含めます
+func (s *connectServiceImpl) GetConnectGraph(ctx context.Context, userID string) (*model.ConnectGraph, error) {
+	pins, err := s.pinRepo.FindByUserID(ctx, userID, model.PinFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+
+	lines, err := s.connectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connects: %w", err)
+	}
+
+	return buildConnectGraph(pins, lines), nil
+}
+
+// buildConnectGraph はPinとConnectからネットワークを組み立てて分析します
+// 頂点に含まれないPin（他のユーザーのPin）を参照するConnectは辺に含めません
+func buildConnectGraph(pins []*model.Pin, lines []*model.ConnectLine) *model.ConnectGraph {
+	g := graph.New()
+	for _, pin := range pins {
+		g.AddNode(pin.ID)
+	}
+	edges := make([]*model.ConnectLine, 0, len(lines))
+	for _, line := range lines {
+		if g.HasNode(line.PinID1) && g.HasNode(line.PinID2) {
+			g.AddEdge(line.ID, line.PinID1, line.PinID2, line.DistanceMeters)
+			edges = append(edges, line)
+		}
+	}
+
+	result := &model.ConnectGraph{
+		Nodes:        make([]*model.ConnectGraphNode, 0, len(pins)),
+		Edges:        edges,
+		Components:   g.Components(),
+		IsolatedPins: g.IsolatedNodes(),
+		Cycles:       []*model.ConnectCycle{},
+	}
+
+	component := make(map[string]int, len(pins))
+	for i, ids := range result.Components {
+		for _, id := range ids {
+			component[id] = i
+		}
+	}
+	for _, pin := range pins {
+		result.Nodes = append(result.Nodes, &model.ConnectGraphNode{
+			Pin:       pin,
+			Degree:    g.Degree(pin.ID),
+			Component: component[pin.ID],
+		})
+	}
+
+	for _, c := range g.Cycles() {
+		result.Cycles = append(result.Cycles, &model.ConnectCycle{
+			PinIDs:     c.Nodes,
+			ConnectIDs: c.Edges,
+		})
+	}
+
+	return result
+}
+
+// EachConnectLineByUser は指定されたユーザーのConnectを両端のPinの座標付きで1件ずつ処理します
+// エクスポートなど、全件をメモリに保持せずに逐次処理する場合に使用します
+func (s *connectServiceImpl) EachConnectLineByUser(ctx context.Context, userID string, fn func(*model.ConnectLine) error) error {
+	return s.connectRepo.EachLineByUserID(ctx, userID, fn)
+}
+
 // DeleteConnect は指定されたConnectを削除します
 // 要件: 9.1, 9.4, 9.6
 func (s *connectServiceImpl) DeleteConnect(ctx context.Context, connectID, userID string) error {
@@ -158,3 +390,60 @@ func (s *connectServiceImpl) DeleteConnect(ctx context.Context, connectID, userI
 
 	return nil
 }
+
+// findConnectLine は作成・更新したConnectを両端のPinの座標付きで取得します
+func (s *connectServiceImpl) findConnectLine(ctx context.Context, connectID string) (*model.ConnectLine, error) {
+	line, err := s.connectRepo.FindByID(ctx, connectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connect: %w", err)
+	}
+	return line, nil
+}
+
+// samePin は2つのPin IDが同じPinを指すかどうかを判定します
+// 大文字・小文字などの表記が異なっても、同じUUIDであれば同じPinとして扱います
+func samePin(pinID1, pinID2 string) bool {
+	id1, err1 := uuid.Parse(pinID1)
+	id2, err2 := uuid.Parse(pinID2)
+	if err1 != nil || err2 != nil {
+		return pinID1 == pinID2
+	}
+	return id1 == id2
+}
+
+// checkConnectablePin はPinがConnectに使用できるかを確認します
+func (s *connectServiceImpl) checkConnectablePin(ctx context.Context, userID, pinID string) error {
+	pin, err := s.pinRepo.FindByID(ctx, pinID)
+	if err != nil || pin == nil {
+		return ErrPinNotExist
+	}
+
+	return checkOwnPin(pin, userID)
+}
+
+// checkOwnPin はPinが自分のPinかを確認します
+// 閲覧できない他のユーザーのPinは存在しないものとして扱います
+func checkOwnPin(pin *model.Pin, userID string) error {
+	if pin.UserID != userID {
+		if pin.Visibility != model.PinVisibilityPublic {
+			return ErrPinNotExist
+		}
+		return ErrPinNotOwned
+	}
+
+	return nil
+}
+
+// checkDuplicateConnect は同じPinの組み合わせのConnectが既に存在するかを確認します
+// excludeIDに指定したConnect（更新対象）は除外します
+func (s *connectServiceImpl) checkDuplicateConnect(ctx context.Context, userID, pinID1, pinID2, excludeID string) error {
+	exists, err := s.connectRepo.ExistsByPinPair(ctx, userID, pinID1, pinID2, excludeID)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicate connect: %w", err)
+	}
+	if exists {
+		return ErrDuplicateConnect
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/mail"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+var (
+	// ErrInvalidVerificationToken はメールアドレス確認用トークンが無効・期限切れ・使用済みのエラー
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	// ErrEmailNotVerified はメールアドレスが未確認のユーザーには許可されていない操作のエラー
+	ErrEmailNotVerified = errors.New("email address is not verified")
+)
+
+// EmailVerificationService はメールアドレスの確認に関するビジネスロジックを提供します
+type EmailVerificationService interface {
+	SendVerification(ctx context.Context, user *model.User) error
+	SendVerificationAsync(user *model.User)
+	VerifyEmail(ctx context.Context, token string) (*model.User, error)
+	ResendVerification(email string)
+}
+
+// emailVerificationServiceImpl はEmailVerificationServiceの実装
+type emailVerificationServiceImpl struct {
+	userRepo  repository.UserRepository
+	mailer    mail.Mailer
+	verifyURL string
+	keySet    *util.KeySet
+	tasks     *BackgroundTasks
+}
+
+// NewEmailVerificationService は新しいEmailVerificationServiceインスタンスを作成します
+// verifyURLを指定すると、確認メールにトークンをtokenパラメータに付けたリンクを記載します
+// SendVerificationAsyncの送信はtasksで実行します
+func NewEmailVerificationService(userRepo repository.UserRepository, mailer mail.Mailer, verifyURL string, keySet *util.KeySet, tasks *BackgroundTasks) EmailVerificationService {
+	return &emailVerificationServiceImpl{
+		userRepo:  userRepo,
+		mailer:    mailer,
+		verifyURL: verifyURL,
+		keySet:    keySet,
+		tasks:     tasks,
+	}
+}
+
+// SendVerification はユーザーのメールアドレスに確認用のトークンを送信します
+func (s *emailVerificationServiceImpl) SendVerification(ctx context.Context, user *model.User) error {
+	token, err := s.keySet.GenerateEmailVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	body := fmt.Sprintf("%s さん\n\nうんちんぐすぽっとへのご登録ありがとうございます。\n", user.Name)
+	if s.verifyURL != "" {
+		body += fmt.Sprintf("次のリンクからメールアドレスを確認してください。\n\n%s?token=%s\n", s.verifyURL, url.QueryEscape(token))
+	} else {
+		body += fmt.Sprintf("次の確認コードでメールアドレスを確認してください。\n\n%s\n", token)
+	}
+	body += fmt.Sprintf("\n有効期限は%d時間です。心当たりがない場合はこのメールを破棄してください。\n", int(util.EmailVerificationTTL.Hours()))
+
+	msg := &mail.Message{
+		To:      user.Email,
+		Subject: "メールアドレスの確認",
+		Body:    body,
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		// 失敗を記録し、クライアントが再送信を促せるようにする
+		if recordErr := s.userRepo.SetVerificationMailFailed(ctx, user.ID, true); recordErr != nil {
+			log.Printf("failed to record verification mail failure: %v", recordErr)
+		}
+		return fmt.Errorf("failed to send verification mail: %w", err)
+	}
+	if user.VerificationMailFailedAt != nil {
+		if err := s.userRepo.SetVerificationMailFailed(ctx, user.ID, false); err != nil {
+			return fmt.Errorf("failed to clear verification mail failure: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SendVerificationAsync はリクエストの処理とは別に確認用のトークンを送信します
+// 送信の失敗はユーザーのverification_mail_failed_atに記録され、再送信で確認できます
+func (s *emailVerificationServiceImpl) SendVerificationAsync(user *model.User) {
+	s.tasks.Go("send verification mail", func(ctx context.Context) error {
+		return s.SendVerification(ctx, user)
+	})
+}
+
+// VerifyEmail はトークンを検証し、ユーザーのメールアドレスを確認済みにします
+// 確認が完了したトークンや、発行後にメールアドレスが変更されたトークンは使用できません
+func (s *emailVerificationServiceImpl) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	claims, err := s.keySet.ValidateEmailVerificationToken(token)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	verified, err := s.userRepo.MarkEmailVerified(ctx, claims.Subject, claims.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+	if !verified {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verified user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ResendVerification はメールアドレスが未確認のユーザーへの確認用のトークンの再送信を、リクエストの処理とは別に実行します
+// 登録の有無を推測されないよう、ユーザーの検索も含めて別に実行し、未登録や確認済みのメールアドレスでも同じ時間で戻ります
+func (s *emailVerificationServiceImpl) ResendVerification(email string) {
+	s.tasks.Go("resend verification mail", func(ctx context.Context) error {
+		return s.resendVerification(ctx, email)
+	})
+}
+
+// resendVerification はメールアドレスが未確認のユーザーに確認用のトークンを再送信します
+// 未登録や確認済みのメールアドレスの場合は何もしません
+func (s *emailVerificationServiceImpl) resendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	return s.SendVerification(ctx, user)
+}
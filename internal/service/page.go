@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+)
+
+// ErrInvalidCursor は無効なページカーソルエラー
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	// DefaultPageLimit は一覧取得のデフォルトのページサイズ
+	DefaultPageLimit = 50
+	// MaxPageLimit は一覧取得の最大ページサイズ
+	MaxPageLimit = 200
+)
+
+// parsePageRequest はクエリで受け取ったカーソルとページサイズを検証します
+// cursorが空の場合は先頭ページとしてnilを返します
+// limitが0以下の場合はDefaultPageLimit、MaxPageLimitを超える場合はMaxPageLimitに丸めます
+func parsePageRequest(cursor string, limit int) (*model.PageCursor, int, error) {
+	limit = pageLimit(limit)
+
+	if cursor == "" {
+		return nil, limit, nil
+	}
+
+	after, err := model.ParsePageCursor(cursor)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	return after, limit, nil
+}
+
+// parseRevisionPageRequest は編集履歴の一覧のカーソルとページサイズを検証します
+// cursorが空の場合は先頭ページとして0を返します。limitはparsePageRequestと同様に丸めます
+func parseRevisionPageRequest(cursor string, limit int) (int, int, error) {
+	limit = pageLimit(limit)
+
+	if cursor == "" {
+		return 0, limit, nil
+	}
+
+	before, err := model.ParseRevisionCursor(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	return before, limit, nil
+}
+
+// newPage はlimit+1件まで取得した要素から1ページ分の要素と次ページのカーソルを作成します
+// limit件を超えている場合のみ、ページの最後の要素をkeyに渡して次ページのカーソルを作成します
+// 結果が空の場合は空のスライスを返します
+func newPage[T any](items []T, limit int, key func(T) string) ([]T, string) {
+	var nextCursor string
+	if len(items) > limit {
+		items = items[:limit]
+		nextCursor = key(items[limit-1])
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+
+	return items, nextCursor
+}
+
+// pageLimit はページサイズをDefaultPageLimit以上MaxPageLimit以下に丸めます
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/mail"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/revocation"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
+)
+
+// PasswordResetTTL はパスワード再設定用トークンの有効期間
+const PasswordResetTTL = time.Hour
+
+// PasswordResetCooldown は同じユーザーにパスワード再設定用トークンを再発行できるまでの間隔
+const PasswordResetCooldown = 5 * time.Minute
+
+// ErrInvalidResetToken はパスワード再設定用トークンが無効・期限切れ・使用済みのエラー
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+// PasswordResetService はパスワードの再設定に関するビジネスロジックを提供します
+type PasswordResetService interface {
+	RequestPasswordReset(email string)
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+// passwordResetServiceImpl はPasswordResetServiceの実装
+type passwordResetServiceImpl struct {
+	userRepo         repository.UserRepository
+	resetTokenRepo   repository.PasswordResetTokenRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	revocationStore  revocation.Store
+	mailer           mail.Mailer
+	resetURL         string
+	tasks            *BackgroundTasks
+}
+
+// NewPasswordResetService は新しいPasswordResetServiceインスタンスを作成します
+// resetURLを指定すると、再設定メールにトークンをtokenパラメータに付けたリンクを記載します
+// 再設定メールの送信はtasksで実行します
+// revocationStoreには認証ミドルウェアと同じStoreを指定してください
+func NewPasswordResetService(userRepo repository.UserRepository, resetTokenRepo repository.PasswordResetTokenRepository, refreshTokenRepo repository.RefreshTokenRepository, revocationStore revocation.Store, mailer mail.Mailer, resetURL string, tasks *BackgroundTasks) PasswordResetService {
+	return &passwordResetServiceImpl{
+		userRepo:         userRepo,
+		resetTokenRepo:   resetTokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+		mailer:           mailer,
+		resetURL:         resetURL,
+		tasks:            tasks,
+	}
+}
+
+// RequestPasswordReset はパスワード再設定用のトークンの発行とメールの送信を、リクエストの処理とは別に実行します
+// 登録の有無を推測されないよう、ユーザーの検索も含めて別に実行し、未登録のメールアドレスでも同じ時間で戻ります
+func (s *passwordResetServiceImpl) RequestPasswordReset(email string) {
+	s.tasks.Go("request password reset", func(ctx context.Context) error {
+		return s.sendPasswordReset(ctx, email)
+	})
+}
+
+// sendPasswordReset はパスワード再設定用のトークンを発行し、メールで送信します
+// 未登録のメールアドレスの場合と、PasswordResetCooldown以内に発行した未使用のトークンがある場合は何もしません
+func (s *passwordResetServiceImpl) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	// 短時間に繰り返し要求されてもメールを大量に送らない
+	recent, err := s.resetTokenRepo.HasRecentUnused(ctx, user.ID, PasswordResetCooldown)
+	if err != nil {
+		return fmt.Errorf("failed to check recent password reset token: %w", err)
+	}
+	if recent {
+		return nil
+	}
+
+	// トークンはハッシュのみを保存する
+	token, err := util.GenerateRandomToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	record := &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: util.HashToken(token),
+	}
+	if err := s.resetTokenRepo.Create(ctx, record, PasswordResetTTL); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	body := fmt.Sprintf("%s さん\n\nパスワードの再設定が要求されました。\n", user.Name)
+	if s.resetURL != "" {
+		body += fmt.Sprintf("次のリンクから新しいパスワードを設定してください。\n\n%s?token=%s\n", s.resetURL, url.QueryEscape(token))
+	} else {
+		body += fmt.Sprintf("次の再設定コードで新しいパスワードを設定してください。\n\n%s\n", token)
+	}
+	body += fmt.Sprintf("\n有効期限は%d分で、1回のみ使用できます。心当たりがない場合はこのメールを破棄してください。\n", int(PasswordResetTTL.Minutes()))
+
+	msg := &mail.Message{
+		To:      user.Email,
+		Subject: "パスワードの再設定",
+		Body:    body,
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send password reset mail: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword はトークンを使用済みにしてパスワードを変更し、既存のセッションを全て失効させます
+// 同じユーザーに発行された他の未使用のトークンも使用できなくなります
+// 盗まれたセッションを使い続けられないよう、成功を返す時点で全ての端末からログアウトされています
+func (s *passwordResetServiceImpl) ResetPassword(ctx context.Context, token, newPassword string) error {
+	hashedPassword, err := util.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	record, err := s.resetTokenRepo.ResetPassword(ctx, util.HashToken(token), hashedPassword)
+	if err != nil {
+		if isNotFoundError(err) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	// 認証ミドルウェアと同じStoreで失効させ、Storeの実装によらずアクセストークンを使用できなくする
+	if err := s.revocationStore.RevokeAll(ctx, record.UserID); err != nil {
+		return fmt.Errorf("failed to revoke all tokens: %w", err)
+	}
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, record.UserID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/photo"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/storage"
+)
+
+var (
+	// ErrPhotoNotFound は写真が見つからないエラー
+	ErrPhotoNotFound = errors.New("photo not found")
+	// ErrUnauthorizedPhotoAccess は写真への不正アクセスエラー
+	ErrUnauthorizedPhotoAccess = errors.New("unauthorized access to photo")
+	// ErrInvalidPhoto は対応していない形式または壊れた画像のエラー
+	ErrInvalidPhoto = errors.New("invalid photo")
+	// ErrPhotoTooLarge はピクセル数が多すぎる画像のエラー
+	ErrPhotoTooLarge = errors.New("photo is too large")
+	// ErrTooManyPhotos はPinに添付できる写真の枚数を超えたエラー
+	ErrTooManyPhotos = errors.New("too many photos")
+)
+
+// PhotoService はPinの写真に関するビジネスロジックを提供します
+type PhotoService interface {
+	UploadPhoto(ctx context.Context, pinID, userID string, data []byte) (*model.Photo, error)
+	GetPhotosByPin(ctx context.Context, pinID, viewerID string) ([]*model.Photo, error)
+	OpenPhoto(ctx context.Context, pinID, photoID, viewerID string, thumbnail bool) (*model.Photo, io.ReadCloser, error)
+	DeletePhoto(ctx context.Context, pinID, photoID, userID string) error
+}
+
+// photoServiceImpl はPhotoServiceの実装
+type photoServiceImpl struct {
+	photoRepo repository.PhotoRepository
+	pinRepo   repository.PinRepository
+	storage   storage.PhotoStorage
+}
+
+// NewPhotoService は新しいPhotoServiceインスタンスを作成します
+func NewPhotoService(photoRepo repository.PhotoRepository, pinRepo repository.PinRepository, photoStorage storage.PhotoStorage) PhotoService {
+	return &photoServiceImpl{
+		photoRepo: photoRepo,
+		pinRepo:   pinRepo,
+		storage:   photoStorage,
+	}
+}
+
+// UploadPhoto はPinに写真を追加します
+// 画像から位置情報を取り除き、サムネイルを生成してからストレージに保存します
+// 写真を追加できるのは自分のPinと公開範囲がpublicのPinのみです
+func (s *photoServiceImpl) UploadPhoto(ctx context.Context, pinID, userID string, data []byte) (*model.Photo, error) {
+	if _, err := findVisiblePin(ctx, s.pinRepo, pinID, userID); err != nil {
+		return nil, err
+	}
+
+	// 画像を処理する前に枚数を確認する（上限は作成時にも確認する）
+	count, err := s.photoRepo.CountByPinID(ctx, pinID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count photos: %w", err)
+	}
+	if count >= model.MaxPhotosPerPin {
+		return nil, ErrTooManyPhotos
+	}
+
+	img, err := photo.Process(data)
+	if err != nil {
+		if errors.Is(err, photo.ErrImageTooLarge) {
+			return nil, ErrPhotoTooLarge
+		}
+		if errors.Is(err, photo.ErrUnsupportedFormat) {
+			return nil, ErrInvalidPhoto
+		}
+		return nil, fmt.Errorf("failed to process photo: %w", err)
+	}
+
+	photoID := uuid.New().String()
+	p := &model.Photo{
+		ID:           photoID,
+		PinID:        pinID,
+		UserID:       userID,
+		StorageKey:   photoKey(pinID, photoID, img.ContentType),
+		ThumbnailKey: photoKey(pinID, photoID+"_thumb", "image/jpeg"),
+		ContentType:  img.ContentType,
+		Width:        img.Width,
+		Height:       img.Height,
+		SizeBytes:    int64(len(img.Data)),
+	}
+
+	// ストレージへの保存
+	if err := s.storage.Put(ctx, p.StorageKey, img.Data, img.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store photo: %w", err)
+	}
+	if err := s.storage.Put(ctx, p.ThumbnailKey, img.Thumbnail, "image/jpeg"); err != nil {
+		s.deleteObjects(ctx, p.StorageKey)
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	created, err := s.photoRepo.Create(ctx, p, model.MaxPhotosPerPin)
+	if err != nil {
+		s.deleteObjects(ctx, p.StorageKey, p.ThumbnailKey)
+		return nil, fmt.Errorf("failed to create photo: %w", err)
+	}
+	if !created {
+		// 同時に追加された写真で上限に達した
+		s.deleteObjects(ctx, p.StorageKey, p.ThumbnailKey)
+		return nil, ErrTooManyPhotos
+	}
+
+	return p, nil
+}
+
+// GetPhotosByPin はPinの写真を追加された順に取得します
+func (s *photoServiceImpl) GetPhotosByPin(ctx context.Context, pinID, viewerID string) ([]*model.Photo, error) {
+	if _, err := findVisiblePin(ctx, s.pinRepo, pinID, viewerID); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.photoRepo.FindByPinID(ctx, pinID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	// 結果が空の場合は空のスライスを返す
+	if photos == nil {
+		photos = []*model.Photo{}
+	}
+
+	return photos, nil
+}
+
+// OpenPhoto は写真の画像本体またはサムネイルを読み取ります
+// 呼び出し元は返されたReadCloserを閉じる必要があります
+func (s *photoServiceImpl) OpenPhoto(ctx context.Context, pinID, photoID, viewerID string, thumbnail bool) (*model.Photo, io.ReadCloser, error) {
+	if _, err := findVisiblePin(ctx, s.pinRepo, pinID, viewerID); err != nil {
+		return nil, nil, err
+	}
+
+	p, err := s.photoRepo.FindByID(ctx, photoID)
+	if err != nil || p.PinID != pinID {
+		return nil, nil, ErrPhotoNotFound
+	}
+
+	key := p.StorageKey
+	if thumbnail {
+		key = p.ThumbnailKey
+	}
+
+	body, err := s.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, ErrPhotoNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open photo: %w", err)
+	}
+
+	return p, body, nil
+}
+
+// DeletePhoto は写真を削除します
+// 削除できるのは写真を追加したユーザーとPinの所有者のみです
+func (s *photoServiceImpl) DeletePhoto(ctx context.Context, pinID, photoID, userID string) error {
+	pin, err := findVisiblePin(ctx, s.pinRepo, pinID, userID)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.photoRepo.FindByID(ctx, photoID)
+	if err != nil || p.PinID != pinID {
+		return ErrPhotoNotFound
+	}
+
+	// 所有権の確認
+	if p.UserID != userID && pin.UserID != userID {
+		return ErrUnauthorizedPhotoAccess
+	}
+
+	if err := s.photoRepo.SoftDelete(ctx, photoID); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+
+	s.deleteObjects(ctx, p.StorageKey, p.ThumbnailKey)
+
+	return nil
+}
+
+// deleteObjects はストレージからオブジェクトを削除します
+// 削除に失敗してもデータベースからは参照されないため、ログに記録して処理を続けます
+func (s *photoServiceImpl) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete photo object %s: %v", key, err)
+		}
+	}
+}
+
+// photoKey はストレージに保存するオブジェクトのキーを組み立てます
+func photoKey(pinID, name, contentType string) string {
+	ext := ".jpg"
+	if contentType == "image/png" {
+		ext = ".png"
+	}
+	return "pins/" + pinID + "/photos/" + name + ext
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/openinghours"
 	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/util"
 )
 
 var (
@@ -17,37 +21,95 @@ var (
 	ErrUnauthorizedPinAccess = errors.New("unauthorized access to pin")
 	// ErrInvalidCoordinates は無効な座標エラー
 	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	// ErrInvalidSearchRadius は無効な検索半径エラー
+	ErrInvalidSearchRadius = errors.New("invalid search radius")
+	// ErrInvalidBoundingBox は無効なバウンディングボックスエラー
+	ErrInvalidBoundingBox = errors.New("invalid bounding box")
+	// ErrInvalidZoom は無効なズームレベルエラー
+	ErrInvalidZoom = errors.New("invalid zoom level")
+	// ErrInvalidTile は無効なタイル座標エラー
+	ErrInvalidTile = errors.New("invalid tile coordinates")
+	// ErrTooManyImportRows はインポート件数が上限を超えたエラー
+	ErrTooManyImportRows = errors.New("too many rows to import")
+	// ErrInvalidVisibility は無効な公開範囲エラー
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	// ErrPinRevisionNotFound はPinの編集履歴が見つからないエラー
+	ErrPinRevisionNotFound = errors.New("pin revision not found")
+	// ErrInvalidPinAttributes は無効な設備属性エラー
+	ErrInvalidPinAttributes = errors.New("invalid pin attributes")
+)
+
+const (
+	// DefaultNearbyLimit は近傍検索のデフォルト取得件数
+	DefaultNearbyLimit = 50
+	// MaxNearbyLimit は近傍検索の最大取得件数
+	MaxNearbyLimit = 200
+	// MaxNearbyRadiusMeters は近傍検索の最大半径（メートル）
+	MaxNearbyRadiusMeters = 50000
+	// MaxBoundsLimit はバウンディングボックス検索の最大取得件数
+	MaxBoundsLimit = 1000
+	// MaxClusterZoom はクラスタリングで受け付ける最大ズームレベル
+	MaxClusterZoom = 22
+	// clusterCellDegreesAtZoom0 はズームレベル0でのクラスタのセルサイズ（度）
+	// 256pxのタイルを64px四方のセルに分割した大きさに相当
+	clusterCellDegreesAtZoom0 = 90.0
+	// MaxImportRows は一括インポートで受け付ける最大行数
+	MaxImportRows = 5000
+	// maxOpenNowCandidates は営業中の絞り込みで評価する候補の最大件数
+	// 営業時間はSQLで評価できないため、候補を取得してから絞り込みます
+	maxOpenNowCandidates = 5000
 )
 
 // PinService はPin関連のビジネスロジックを提供します
 type PinService interface {
-	CreatePin(ctx context.Context, userID string, name string, lat, lng float64) (*model.Pin, error)
-	UpdatePin(ctx context.Context, pinID, userID string, name string, lat, lng float64) (*model.Pin, error)
-	GetPin(ctx context.Context, pinID string) (*model.Pin, error)
-	GetPinsByUser(ctx context.Context, userID string) ([]*model.Pin, error)
+	CreatePin(ctx context.Context, userID string, name string, lat, lng float64, attrs model.PinAttributes) (*model.Pin, error)
+	ImportPins(ctx context.Context, userID string, rows []*model.PinImportRow) (*model.PinImportReport, error)
+	UpdatePin(ctx context.Context, pinID, userID string, name string, lat, lng float64, attrs model.PinAttributesUpdate) (*model.Pin, error)
+	SetPinVisibility(ctx context.Context, pinID, userID, visibility string) (*model.Pin, error)
+	GetPinHistory(ctx context.Context, pinID, userID, cursor string, limit int) (*model.PinRevisionPage, error)
+	RevertPin(ctx context.Context, pinID, userID string, revision int) (*model.Pin, error)
+	GetPin(ctx context.Context, pinID, viewerID string) (*model.Pin, error)
+	GetSharedPin(ctx context.Context, token string) (*model.Pin, error)
+	GetPublicPinPage(ctx context.Context, cursor string, limit int) (*model.PinPage, error)
+	GetPinsByUser(ctx context.Context, userID string, filter model.PinFilter) ([]*model.Pin, error)
+	GetPinPageByUser(ctx context.Context, userID string, filter model.PinFilter, cursor string, limit int) (*model.PinPage, error)
+	EachPinByUser(ctx context.Context, userID string, fn func(*model.Pin) error) error
+	SearchNearbyPins(ctx context.Context, userID string, lat, lng, radiusMeters float64, filter model.PinFilter, limit int) ([]*model.NearbyPin, error)
+	GetPinsInBounds(ctx context.Context, userID string, bbox model.BoundingBox, filter model.PinFilter, limit int) (*model.PinsInBounds, error)
+	GetPinClusters(ctx context.Context, userID string, bbox model.BoundingBox, zoom int) ([]*model.PinCluster, error)
+	GetPinTile(ctx context.Context, z, x, y int, viewerID, filterUserID string) ([]byte, error)
 	DeletePin(ctx context.Context, pinID, userID string) error
 }
 
 // pinServiceImpl はPinServiceの実装
 type pinServiceImpl struct {
-	pinRepo repository.PinRepository
+	pinRepo  repository.PinRepository
+	userRepo repository.UserRepository
 }
 
 // NewPinService は新しいPinServiceインスタンスを作成します
-func NewPinService(pinRepo repository.PinRepository) PinService {
+// userRepoはPinを公開する際にメールアドレスが確認済みかを確認するために使用します
+func NewPinService(pinRepo repository.PinRepository, userRepo repository.UserRepository) PinService {
 	return &pinServiceImpl{
-		pinRepo: pinRepo,
+		pinRepo:  pinRepo,
+		userRepo: userRepo,
 	}
 }
 
 // CreatePin は新しいPinを作成します
 // 要件: 6.1, 6.2, 6.3, 6.4, 6.5
-func (s *pinServiceImpl) CreatePin(ctx context.Context, userID string, name string, lat, lng float64) (*model.Pin, error) {
+func (s *pinServiceImpl) CreatePin(ctx context.Context, userID string, name string, lat, lng float64, attrs model.PinAttributes) (*model.Pin, error) {
 	// 座標の検証
 	if !isValidCoordinates(lat, lng) {
 		return nil, ErrInvalidCoordinates
 	}
 
+	// 設備属性の検証
+	attrs, err := normalizePinAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+
 	// 新しいPinの作成（要件: 6.1, 6.2, 6.3, 6.4）
 	now := time.Now()
 	pin := &model.Pin{
@@ -58,6 +120,7 @@ func (s *pinServiceImpl) CreatePin(ctx context.Context, userID string, name stri
 		CreatedAt: now,
 		EditedAt:  now,
 	}
+	pin.PinAttributes = attrs
 
 	if err := s.pinRepo.Create(ctx, pin); err != nil {
 		return nil, fmt.Errorf("failed to create pin: %w", err)
@@ -67,9 +130,100 @@ func (s *pinServiceImpl) CreatePin(ctx context.Context, userID string, name stri
 	return pin, nil
 }
 
+// ImportPins はファイルから読み取った複数のPinを一括で作成します
+// 各行を検証し、有効な行のみを1つのトランザクションで作成して行ごとの結果を返します
+// 同じ名前・同じ座標のPinが既に存在する行や、ファイル内で重複する行はスキップします
+func (s *pinServiceImpl) ImportPins(ctx context.Context, userID string, rows []*model.PinImportRow) (*model.PinImportReport, error) {
+	if len(rows) > MaxImportRows {
+		return nil, ErrTooManyImportRows
+	}
+
+	// 重複判定のため既存のPinを取得
+	existing, err := s.pinRepo.FindByUserID(ctx, userID, model.PinFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins: %w", err)
+	}
+	seen := make(map[string]bool, len(existing)+len(rows))
+	for _, pin := range existing {
+		seen[importKey(pin.Name, pin.Latitude, pin.Longitude)] = true
+	}
+
+	report := &model.PinImportReport{
+		Rows: make([]*model.PinImportRowResult, 0, len(rows)),
+	}
+	pins := make([]*model.Pin, 0, len(rows))
+	created := make([]*model.PinImportRowResult, 0, len(rows))
+
+	for _, row := range rows {
+		result := &model.PinImportRowResult{
+			Index:     row.Index,
+			Name:      row.Name,
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+		}
+		report.Rows = append(report.Rows, result)
+
+		// 行の検証
+		var reason error
+		switch {
+		case row.Err != nil:
+			reason = row.Err
+		case strings.TrimSpace(row.Name) == "":
+			reason = errors.New("name is required")
+		default:
+			reason = util.ValidateCoordinates(row.Latitude, row.Longitude)
+		}
+		if reason != nil {
+			result.Reason = reason.Error()
+			if row.Skip {
+				result.Status = model.ImportStatusSkipped
+				report.Skipped++
+			} else {
+				result.Status = model.ImportStatusInvalid
+				report.Invalid++
+			}
+			continue
+		}
+
+		// 重複の確認
+		key := importKey(row.Name, row.Latitude, row.Longitude)
+		if seen[key] {
+			result.Status = model.ImportStatusSkipped
+			result.Reason = "duplicate pin"
+			report.Skipped++
+			continue
+		}
+		seen[key] = true
+
+		pins = append(pins, &model.Pin{
+			Name:      row.Name,
+			UserID:    userID,
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+		})
+		created = append(created, result)
+	}
+
+	// 有効な行を1つのトランザクションで作成
+	if len(pins) > 0 {
+		if err := s.pinRepo.CreateBatch(ctx, pins); err != nil {
+			return nil, fmt.Errorf("failed to import pins: %w", err)
+		}
+	}
+
+	for i, result := range created {
+		result.Status = model.ImportStatusCreated
+		result.PinID = pins[i].ID
+	}
+	report.Created = len(created)
+
+	return report, nil
+}
+
 // UpdatePin は既存のPinを更新します
 // 要件: 7.1, 7.2, 7.3, 7.4, 7.5
-func (s *pinServiceImpl) UpdatePin(ctx context.Context, pinID, userID string, name string, lat, lng float64) (*model.Pin, error) {
+// 設備属性はリクエストの内容で全て置き換えます
+func (s *pinServiceImpl) UpdatePin(ctx context.Context, pinID, userID string, name string, lat, lng float64, attrs model.PinAttributesUpdate) (*model.Pin, error) {
 	// 座標の検証
 	if !isValidCoordinates(lat, lng) {
 		return nil, ErrInvalidCoordinates
@@ -86,13 +240,20 @@ func (s *pinServiceImpl) UpdatePin(ctx context.Context, pinID, userID string, na
 		return nil, ErrUnauthorizedPinAccess
 	}
 
+	// 指定された設備属性のみを変更し、変更後の組み合わせを検証する
+	pinAttrs, err := normalizePinAttributes(attrs.Apply(pin.PinAttributes))
+	if err != nil {
+		return nil, err
+	}
+
 	// Pinの更新（要件: 7.1, 7.2, 7.3）
 	pin.Name = name
 	pin.Latitude = lat
 	pin.Longitude = lng
+	pin.PinAttributes = pinAttrs
 	pin.EditedAt = time.Now()
 
-	if err := s.pinRepo.Update(ctx, pin); err != nil {
+	if err := s.pinRepo.Update(ctx, pin, userID); err != nil {
 		return nil, fmt.Errorf("failed to update pin: %w", err)
 	}
 
@@ -100,24 +261,174 @@ func (s *pinServiceImpl) UpdatePin(ctx context.Context, pinID, userID string, na
 	return pin, nil
 }
 
+// GetPinHistory はPinの編集履歴を新しい順に1ページ分取得します
+// 過去の位置を含むため、取得できるのはPinの所有者のみです
+func (s *pinServiceImpl) GetPinHistory(ctx context.Context, pinID, userID, cursor string, limit int) (*model.PinRevisionPage, error) {
+	before, limit, err := parseRevisionPageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, err := s.pinRepo.FindByID(ctx, pinID)
+	if err != nil {
+		return nil, ErrPinNotFound
+	}
+
+	// 所有権の確認
+	if pin.UserID != userID {
+		return nil, ErrUnauthorizedPinAccess
+	}
+
+	revisions, err := s.pinRepo.FindRevisionPage(ctx, pinID, before, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pin history: %w", err)
+	}
+
+	page := &model.PinRevisionPage{}
+	page.Items, page.NextCursor = newPage(revisions, limit, func(rev *model.PinRevision) string {
+		return model.EncodeRevisionCursor(rev.Revision)
+	})
+
+	return page, nil
+}
+
+// RevertPin はPinの名前と位置を指定されたリビジョンの更新前の状態に戻します
+// 設備属性は現在の値を維持します。取り消しはUpdatePinを通して行うため、所有権の確認と編集履歴の記録も同様に行われます
+func (s *pinServiceImpl) RevertPin(ctx context.Context, pinID, userID string, revision int) (*model.Pin, error) {
+	pin, err := s.pinRepo.FindByID(ctx, pinID)
+	if err != nil {
+		return nil, ErrPinNotFound
+	}
+
+	// 所有権の確認（他のユーザーにはリビジョンの有無も返さない）
+	if pin.UserID != userID {
+		return nil, ErrUnauthorizedPinAccess
+	}
+
+	rev, err := s.pinRepo.FindRevision(ctx, pinID, revision)
+	if err != nil {
+		return nil, ErrPinRevisionNotFound
+	}
+
+	return s.UpdatePin(ctx, pinID, userID, rev.OldName, rev.OldLatitude, rev.OldLongitude, model.PinAttributesUpdate{})
+}
+
+// SetPinVisibility はPinの公開範囲を変更します
+// shared-by-linkに変更した場合は共有リンク用トークンを発行し、それ以外に変更した場合は破棄します
+// 既に共有リンクが発行されている場合は同じトークンを使い続けます
+// publicに変更できるのはメールアドレスが確認済みのユーザーのみです
+func (s *pinServiceImpl) SetPinVisibility(ctx context.Context, pinID, userID, visibility string) (*model.Pin, error) {
+	if !model.IsValidPinVisibility(visibility) {
+		return nil, ErrInvalidVisibility
+	}
+
+	// 既存のPinを取得
+	pin, err := s.pinRepo.FindByID(ctx, pinID)
+	if err != nil {
+		return nil, ErrPinNotFound
+	}
+
+	// 所有権の確認
+	if pin.UserID != userID {
+		return nil, ErrUnauthorizedPinAccess
+	}
+
+	// 未確認のメールアドレスで登録したユーザーはPinを公開できない
+	if visibility == model.PinVisibilityPublic && pin.Visibility != model.PinVisibilityPublic {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsEmailVerified() {
+			return nil, ErrEmailNotVerified
+		}
+	}
+
+	pin.Visibility = visibility
+	if visibility != model.PinVisibilitySharedByLink {
+		pin.ShareToken = nil
+	} else if pin.ShareToken == nil {
+		token, err := util.GenerateRandomToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate share token: %w", err)
+		}
+		pin.ShareToken = &token
+	}
+
+	if err := s.pinRepo.UpdateVisibility(ctx, pin); err != nil {
+		return nil, fmt.Errorf("failed to update pin visibility: %w", err)
+	}
+
+	return pin, nil
+}
+
 // GetPin は指定されたIDのPinを取得します
+// 所有者以外は公開範囲がpublicのPinのみ取得でき、それ以外はPinの存在を明かさないためErrPinNotFoundを返します
 // 要件: 6.1, 7.1
-func (s *pinServiceImpl) GetPin(ctx context.Context, pinID string) (*model.Pin, error) {
+func (s *pinServiceImpl) GetPin(ctx context.Context, pinID, viewerID string) (*model.Pin, error) {
 	pin, err := s.pinRepo.FindByID(ctx, pinID)
 	if err != nil {
 		return nil, ErrPinNotFound
 	}
 
+	if pin.UserID != viewerID {
+		if pin.Visibility != model.PinVisibilityPublic {
+			return nil, ErrPinNotFound
+		}
+		pin.ShareToken = nil
+	}
+
+	return pin, nil
+}
+
+// GetSharedPin は共有リンク用トークンでPinを取得します
+// 公開範囲がshared-by-link以外に変更されたPinのトークンは無効になります
+func (s *pinServiceImpl) GetSharedPin(ctx context.Context, token string) (*model.Pin, error) {
+	if token == "" {
+		return nil, ErrPinNotFound
+	}
+
+	pin, err := s.pinRepo.FindByShareToken(ctx, token)
+	if err != nil || pin.Visibility != model.PinVisibilitySharedByLink {
+		return nil, ErrPinNotFound
+	}
+
+	pin.ShareToken = nil
 	return pin, nil
 }
 
+// GetPublicPinPage は公開範囲がpublicのPinを新しい順に1ページ分取得します
+func (s *pinServiceImpl) GetPublicPinPage(ctx context.Context, cursor string, limit int) (*model.PinPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := s.pinRepo.FindPublicPage(ctx, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public pins: %w", err)
+	}
+
+	page := newPinPage(pins, limit)
+
+	// 公開フィードでは共有リンク用トークンを返さない
+	for _, pin := range page.Items {
+		pin.ShareToken = nil
+	}
+
+	return page, nil
+}
+
 // GetPinsByUser は指定されたユーザーの全Pinを取得します
 // 要件: 6.1, 7.1
-func (s *pinServiceImpl) GetPinsByUser(ctx context.Context, userID string) ([]*model.Pin, error) {
-	pins, err := s.pinRepo.FindByUserID(ctx, userID)
+func (s *pinServiceImpl) GetPinsByUser(ctx context.Context, userID string, filter model.PinFilter) ([]*model.Pin, error) {
+	pins, err := s.pinRepo.FindByUserID(ctx, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pins: %w", err)
 	}
+	if filter.OpenAt != nil {
+		pins = filterOpenPins(pins, filter)
+	}
 
 	// 結果が空の場合は空のスライスを返す
 	if pins == nil {
@@ -127,6 +438,214 @@ func (s *pinServiceImpl) GetPinsByUser(ctx context.Context, userID string) ([]*m
 	return pins, nil
 }
 
+// GetPinPageByUser は指定されたユーザーのPinを新しい順に1ページ分取得します
+// 営業中で絞り込む場合は、1ページ分の営業中のPinが揃うまで続きの行を読み進めます
+func (s *pinServiceImpl) GetPinPageByUser(ctx context.Context, userID string, filter model.PinFilter, cursor string, limit int) (*model.PinPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.OpenAt == nil {
+		pins, err := s.pinRepo.FindPageByUserID(ctx, userID, after, filter, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pins: %w", err)
+		}
+		return newPinPage(pins, limit), nil
+	}
+
+	pins := make([]*model.Pin, 0, limit+1)
+	more := true
+	for scanned := 0; more && len(pins) <= limit && scanned < maxOpenNowCandidates; {
+		batch, err := s.pinRepo.FindPageByUserID(ctx, userID, after, filter, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pins: %w", err)
+		}
+		pins = append(pins, filterOpenPins(batch, filter)...)
+		scanned += len(batch)
+
+		more = len(batch) > limit
+		if more {
+			last := batch[len(batch)-1]
+			after = model.NewPageCursor(last.CreatedAt, last.ID)
+		}
+	}
+
+	page := newPinPage(pins, limit)
+	// 評価する候補の上限に達した場合は、読み進めた位置から続きを取得できるようにする
+	if page.NextCursor == "" && more {
+		page.NextCursor = after.Encode()
+	}
+	return page, nil
+}
+
+// newPinPage はlimit+1件まで取得したPinから1ページ分の結果を作成します
+// limit件を超えている場合のみ、最後の要素からNextCursorを設定します
+func newPinPage(pins []*model.Pin, limit int) *model.PinPage {
+	page := &model.PinPage{}
+	page.Items, page.NextCursor = newPage(pins, limit, func(pin *model.Pin) string {
+		return model.NewPageCursor(pin.CreatedAt, pin.ID).Encode()
+	})
+
+	return page
+}
+
+// EachPinByUser は指定されたユーザーのPinを1件ずつ処理します
+// エクスポートなど、全件をメモリに保持せずに逐次処理する場合に使用します
+func (s *pinServiceImpl) EachPinByUser(ctx context.Context, userID string, fn func(*model.Pin) error) error {
+	return s.pinRepo.EachByUserID(ctx, userID, fn)
+}
+
+// SearchNearbyPins は指定地点から半径radiusMeters以内のユーザーのPinを近い順に取得します
+// limitが0以下の場合はDefaultNearbyLimit、MaxNearbyLimitを超える場合はMaxNearbyLimitに丸めます
+func (s *pinServiceImpl) SearchNearbyPins(ctx context.Context, userID string, lat, lng, radiusMeters float64, filter model.PinFilter, limit int) ([]*model.NearbyPin, error) {
+	// 座標の検証
+	if !isValidCoordinates(lat, lng) {
+		return nil, ErrInvalidCoordinates
+	}
+
+	// 検索半径の検証（NaNはどの比較も偽になるため個別に弾く）
+	if math.IsNaN(radiusMeters) || math.IsInf(radiusMeters, 0) || radiusMeters <= 0 || radiusMeters > MaxNearbyRadiusMeters {
+		return nil, ErrInvalidSearchRadius
+	}
+
+	if limit <= 0 {
+		limit = DefaultNearbyLimit
+	}
+	if limit > MaxNearbyLimit {
+		limit = MaxNearbyLimit
+	}
+
+	// 営業中で絞り込む場合は候補を多めに取得してから近い順にlimit件を選ぶ
+	fetchLimit := limit
+	if filter.OpenAt != nil {
+		fetchLimit = maxOpenNowCandidates
+	}
+
+	pins, err := s.pinRepo.FindNearby(ctx, userID, lat, lng, radiusMeters, filter, fetchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search nearby pins: %w", err)
+	}
+
+	if filter.OpenAt != nil {
+		open := make([]*model.NearbyPin, 0, limit)
+		for _, pin := range pins {
+			if len(open) == limit {
+				break
+			}
+			if isPinOpen(&pin.Pin, filter) {
+				open = append(open, pin)
+			}
+		}
+		pins = open
+	}
+
+	// 結果が空の場合は空のスライスを返す
+	if pins == nil {
+		pins = []*model.NearbyPin{}
+	}
+
+	return pins, nil
+}
+
+// GetPinsInBounds はバウンディングボックス内のユーザーのPinを取得します
+// 結果がlimit件を超える場合は切り詰め、Truncatedをtrueにして返します
+func (s *pinServiceImpl) GetPinsInBounds(ctx context.Context, userID string, bbox model.BoundingBox, filter model.PinFilter, limit int) (*model.PinsInBounds, error) {
+	// 範囲の検証
+	if !isValidBoundingBox(bbox) {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	if limit <= 0 || limit > MaxBoundsLimit {
+		limit = MaxBoundsLimit
+	}
+
+	// 切り詰めの有無を判定するため1件多く取得
+	// 営業中で絞り込む場合は候補を多めに取得してから絞り込む
+	fetchLimit := limit + 1
+	if filter.OpenAt != nil {
+		fetchLimit = maxOpenNowCandidates + 1
+	}
+
+	pins, err := s.pinRepo.FindWithinBounds(ctx, userID, bbox, filter, fetchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pins in bounds: %w", err)
+	}
+
+	result := &model.PinsInBounds{
+		Pins: pins,
+	}
+	if filter.OpenAt != nil {
+		// 候補が上限を超えた場合は評価しきれなかったPinがあるため切り詰めとして扱う
+		if len(pins) > maxOpenNowCandidates {
+			pins = pins[:maxOpenNowCandidates]
+			result.Truncated = true
+		}
+		pins = filterOpenPins(pins, filter)
+		result.Pins = pins
+	}
+	if len(pins) > limit {
+		result.Pins = pins[:limit]
+		result.Truncated = true
+	}
+
+	// 結果が空の場合は空のスライスを返す
+	if result.Pins == nil {
+		result.Pins = []*model.Pin{}
+	}
+
+	return result, nil
+}
+
+// GetPinClusters はバウンディングボックス内のユーザーのPinをズームレベルに応じたグリッドで集約します
+// セルサイズはズームレベルが1上がるごとに半分になります
+func (s *pinServiceImpl) GetPinClusters(ctx context.Context, userID string, bbox model.BoundingBox, zoom int) ([]*model.PinCluster, error) {
+	// 範囲の検証
+	if !isValidBoundingBox(bbox) {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	// ズームレベルの検証
+	if zoom < 0 || zoom > MaxClusterZoom {
+		return nil, ErrInvalidZoom
+	}
+
+	gridSize := clusterCellDegreesAtZoom0 / float64(int(1)<<zoom)
+
+	clusters, err := s.pinRepo.FindClusters(ctx, userID, bbox, gridSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pin clusters: %w", err)
+	}
+
+	// 結果が空の場合は空のスライスを返す
+	if clusters == nil {
+		clusters = []*model.PinCluster{}
+	}
+
+	return clusters, nil
+}
+
+// GetPinTile は指定タイル座標（z/x/y）のPinをMapbox Vector Tile形式で取得します
+// viewerIDのユーザーが閲覧できるPin（公開範囲がpublicのPinと自分のPin）のみを含めます
+// filterUserIDが空でない場合はさらにそのユーザーのPinのみに絞り込みます
+func (s *pinServiceImpl) GetPinTile(ctx context.Context, z, x, y int, viewerID, filterUserID string) ([]byte, error) {
+	// タイル座標の検証
+	if z < 0 || z > MaxClusterZoom {
+		return nil, ErrInvalidTile
+	}
+	n := 1 << z
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return nil, ErrInvalidTile
+	}
+
+	tile, err := s.pinRepo.FindTile(ctx, z, x, y, viewerID, filterUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pin tile: %w", err)
+	}
+
+	return tile, nil
+}
+
 // DeletePin は指定されたPinを削除します（ソフトデリート）
 // 要件: 7.1, 7.4, 7.5
 func (s *pinServiceImpl) DeletePin(ctx context.Context, pinID, userID string) error {
@@ -149,7 +668,101 @@ func (s *pinServiceImpl) DeletePin(ctx context.Context, pinID, userID string) er
 	return nil
 }
 
+// findVisiblePin はユーザーが閲覧できるPinを取得します
+// 閲覧できるのは自分のPinと公開範囲がpublicのPinのみで、それ以外はPinの存在を明かさないためErrPinNotFoundを返します
+func findVisiblePin(ctx context.Context, pinRepo repository.PinRepository, pinID, userID string) (*model.Pin, error) {
+	pin, err := pinRepo.FindByID(ctx, pinID)
+	if err != nil {
+		return nil, ErrPinNotFound
+	}
+	if pin.UserID != userID && pin.Visibility != model.PinVisibilityPublic {
+		return nil, ErrPinNotFound
+	}
+	return pin, nil
+}
+
 // isValidCoordinates は座標が有効範囲内かチェックします
 func isValidCoordinates(lat, lng float64) bool {
 	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
 }
+
+// isValidBoundingBox はバウンディングボックスが有効かチェックします
+// 経度は日付変更線をまたぐ範囲（MinLng > MaxLng）を許可します
+func isValidBoundingBox(bbox model.BoundingBox) bool {
+	if !isValidCoordinates(bbox.MinLat, bbox.MinLng) || !isValidCoordinates(bbox.MaxLat, bbox.MaxLng) {
+		return false
+	}
+	return bbox.MinLat <= bbox.MaxLat
+}
+
+// normalizePinAttributes は設備属性を検証し、営業時間とタイムゾーンの前後の空白を取り除きます
+// 営業時間やタイムゾーンが空文字列の場合は未設定として扱います
+// 営業時間はPinの現地時間で評価するため、営業時間を設定する場合はタイムゾーンも必須です
+func normalizePinAttributes(attrs model.PinAttributes) (model.PinAttributes, error) {
+	if util.ValidateFee(attrs.Paid, attrs.FeeAmount) != nil {
+		return attrs, ErrInvalidPinAttributes
+	}
+
+	if attrs.OpeningHours != nil {
+		openingHours := strings.TrimSpace(*attrs.OpeningHours)
+		if openingHours == "" {
+			attrs.OpeningHours = nil
+		} else if util.ValidateOpeningHours(openingHours) != nil {
+			return attrs, ErrInvalidPinAttributes
+		} else {
+			attrs.OpeningHours = &openingHours
+		}
+	}
+
+	if attrs.Timezone != nil {
+		timezone := strings.TrimSpace(*attrs.Timezone)
+		if timezone == "" {
+			attrs.Timezone = nil
+		} else if util.ValidateTimezone(timezone) != nil {
+			return attrs, ErrInvalidPinAttributes
+		} else {
+			attrs.Timezone = &timezone
+		}
+	}
+
+	if attrs.OpeningHours != nil && attrs.Timezone == nil {
+		return attrs, ErrInvalidPinAttributes
+	}
+
+	return attrs, nil
+}
+
+// isPinOpen はPinがfilter.OpenAtの時刻に営業中かどうかを判定します
+// 時刻はPinのタイムゾーンの現地時間で評価します
+// 営業時間やタイムゾーンが不明なPinは営業中として扱いません
+func isPinOpen(pin *model.Pin, filter model.PinFilter) bool {
+	if pin.OpeningHours == nil || pin.Timezone == nil {
+		return false
+	}
+	schedule, err := openinghours.Parse(*pin.OpeningHours)
+	if err != nil {
+		return false
+	}
+	loc, err := util.LoadTimezone(*pin.Timezone)
+	if err != nil {
+		return false
+	}
+	return schedule.IsOpen(filter.OpenAt.In(loc))
+}
+
+// filterOpenPins はfilter.OpenAtの時刻に営業中のPinのみを返します
+func filterOpenPins(pins []*model.Pin, filter model.PinFilter) []*model.Pin {
+	open := make([]*model.Pin, 0, len(pins))
+	for _, pin := range pins {
+		if isPinOpen(pin, filter) {
+			open = append(open, pin)
+		}
+	}
+	return open
+}
+
+// importKey はインポート時の重複判定に使うキーを返します
+// 座標は小数点以下6桁（約10cm）で丸めて比較します
+func importKey(name string, lat, lng float64) string {
+	return fmt.Sprintf("%s|%.6f|%.6f", strings.TrimSpace(name), lat, lng)
+}
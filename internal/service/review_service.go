@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+)
+
+var (
+	// ErrReviewNotFound はレビューが見つからないエラー
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrUnauthorizedReviewAccess はレビューへの不正アクセスエラー
+	ErrUnauthorizedReviewAccess = errors.New("unauthorized access to review")
+	// ErrReviewAlreadyExists は同じPinへのレビューが既に存在するエラー
+	ErrReviewAlreadyExists = errors.New("review already exists")
+	// ErrInvalidReview は無効なスコアまたはコメントのエラー
+	ErrInvalidReview = errors.New("invalid review")
+)
+
+// ReviewService はレビュー関連のビジネスロジックを提供します
+type ReviewService interface {
+	CreateReview(ctx context.Context, pinID, userID string, scores model.ReviewScores) (*model.Review, error)
+	UpdateReview(ctx context.Context, pinID, reviewID, userID string, scores model.ReviewScores) (*model.Review, error)
+	GetReviewPage(ctx context.Context, pinID, viewerID, cursor string, limit int) (*model.ReviewPage, error)
+	DeleteReview(ctx context.Context, pinID, reviewID, userID string) error
+}
+
+// reviewServiceImpl はReviewServiceの実装
+type reviewServiceImpl struct {
+	reviewRepo repository.ReviewRepository
+	pinRepo    repository.PinRepository
+}
+
+// NewReviewService は新しいReviewServiceインスタンスを作成します
+func NewReviewService(reviewRepo repository.ReviewRepository, pinRepo repository.PinRepository) ReviewService {
+	return &reviewServiceImpl{
+		reviewRepo: reviewRepo,
+		pinRepo:    pinRepo,
+	}
+}
+
+// CreateReview はPinに新しいレビューを作成します
+// 1人のユーザーは1つのPinに1件のみレビューでき、既に存在する場合はErrReviewAlreadyExistsを返します
+func (s *reviewServiceImpl) CreateReview(ctx context.Context, pinID, userID string, scores model.ReviewScores) (*model.Review, error) {
+	scores, err := normalizeReviewScores(scores)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findVisiblePin(ctx, s.pinRepo, pinID, userID); err != nil {
+		return nil, err
+	}
+
+	// 既存のレビューの確認
+	existing, err := s.reviewRepo.FindByPinAndUser(ctx, pinID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrReviewAlreadyExists
+	}
+
+	review := &model.Review{
+		PinID:        pinID,
+		UserID:       userID,
+		ReviewScores: scores,
+	}
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		// 同時に作成された場合はデータベースの一意制約違反になる
+		if isUniqueViolation(err) {
+			return nil, ErrReviewAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return review, nil
+}
+
+// UpdateReview は自分のレビューのスコアとコメントを更新します
+func (s *reviewServiceImpl) UpdateReview(ctx context.Context, pinID, reviewID, userID string, scores model.ReviewScores) (*model.Review, error) {
+	scores, err := normalizeReviewScores(scores)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.findOwnReview(ctx, pinID, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.ReviewScores = scores
+
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+
+	return review, nil
+}
+
+// GetReviewPage はPinのレビューを新しい順に1ページ分取得します
+// 閲覧できるのは自分のPinと公開範囲がpublicのPinのレビューのみです
+func (s *reviewServiceImpl) GetReviewPage(ctx context.Context, pinID, viewerID, cursor string, limit int) (*model.ReviewPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findVisiblePin(ctx, s.pinRepo, pinID, viewerID); err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.reviewRepo.FindPageByPinID(ctx, pinID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	page := &model.ReviewPage{}
+	page.Items, page.NextCursor = newPage(reviews, limit, func(review *model.Review) string {
+		return model.NewPageCursor(review.CreatedAt, review.ID).Encode()
+	})
+
+	return page, nil
+}
+
+// DeleteReview は自分のレビューを削除します（ソフトデリート）
+func (s *reviewServiceImpl) DeleteReview(ctx context.Context, pinID, reviewID, userID string) error {
+	if _, err := s.findOwnReview(ctx, pinID, reviewID, userID); err != nil {
+		return err
+	}
+
+	if err := s.reviewRepo.SoftDelete(ctx, reviewID); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	return nil
+}
+
+// findOwnReview は指定されたPinに対する自分のレビューを取得します
+func (s *reviewServiceImpl) findOwnReview(ctx context.Context, pinID, reviewID, userID string) (*model.Review, error) {
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil || review.PinID != pinID {
+		return nil, ErrReviewNotFound
+	}
+
+	// 所有権の確認
+	if review.UserID != userID {
+		return nil, ErrUnauthorizedReviewAccess
+	}
+
+	return review, nil
+}
+
+// normalizeReviewScores はスコアの範囲とコメントの長さを検証し、コメントの前後の空白を取り除きます
+func normalizeReviewScores(scores model.ReviewScores) (model.ReviewScores, error) {
+	for _, score := range []int{scores.Cleanliness, scores.Crowding, scores.PaperAvailability} {
+		if score < model.MinReviewScore || score > model.MaxReviewScore {
+			return scores, ErrInvalidReview
+		}
+	}
+
+	scores.Comment = strings.TrimSpace(scores.Comment)
+	if utf8.RuneCountInString(scores.Comment) > model.MaxReviewCommentLength {
+		return scores, ErrInvalidReview
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/model"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/repository"
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/storage"
+)
+
+const (
+	// DefaultTrashRetention はゴミ箱のPinを完全に削除するまでのデフォルトの保持期間
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// purgeBatchSize は保持期間を過ぎたPinを1回の検索で取得する件数
+	purgeBatchSize = 100
+)
+
+// TrashService は論理削除されたPin（ゴミ箱）に関するビジネスロジックを提供します
+type TrashService interface {
+	GetTrashPage(ctx context.Context, userID, cursor string, limit int) (*model.PinPage, error)
+	RestorePin(ctx context.Context, pinID, userID string) (*model.Pin, error)
+	PurgePin(ctx context.Context, pinID, userID string) error
+	PurgeExpired(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// trashServiceImpl はTrashServiceの実装
+type trashServiceImpl struct {
+	pinRepo repository.PinRepository
+	storage storage.PhotoStorage
+}
+
+// NewTrashService は新しいTrashServiceインスタンスを作成します
+// 完全に削除したPinの写真はphotoStorageからも削除します
+func NewTrashService(pinRepo repository.PinRepository, photoStorage storage.PhotoStorage) TrashService {
+	return &trashServiceImpl{
+		pinRepo: pinRepo,
+		storage: photoStorage,
+	}
+}
+
+// GetTrashPage はユーザーのゴミ箱にあるPinを削除日時の新しい順に1ページ分取得します
+func (s *trashServiceImpl) GetTrashPage(ctx context.Context, userID, cursor string, limit int) (*model.PinPage, error) {
+	after, limit, err := parsePageRequest(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := s.pinRepo.FindTrashPageByUserID(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted pins: %w", err)
+	}
+
+	page := &model.PinPage{}
+	page.Items, page.NextCursor = newPage(pins, limit, func(pin *model.Pin) string {
+		return model.NewPageCursor(*pin.DeletedAt, pin.ID).Encode()
+	})
+
+	return page, nil
+}
+
+// RestorePin はゴミ箱にあるPinを元に戻します
+// Pinと同時に削除された写真と、Pinを参照するConnectも再び表示されます
+func (s *trashServiceImpl) RestorePin(ctx context.Context, pinID, userID string) (*model.Pin, error) {
+	if err := s.findOwnDeletedPin(ctx, pinID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.pinRepo.Restore(ctx, pinID); err != nil {
+		return nil, fmt.Errorf("failed to restore pin: %w", err)
+	}
+
+	pin, err := s.pinRepo.FindByID(ctx, pinID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restored pin: %w", err)
+	}
+
+	return pin, nil
+}
+
+// PurgePin はゴミ箱にあるPinを完全に削除します
+// 写真、レビュー、編集履歴、Pinを参照するConnectも削除され、元に戻せません
+func (s *trashServiceImpl) PurgePin(ctx context.Context, pinID, userID string) error {
+	if err := s.findOwnDeletedPin(ctx, pinID, userID); err != nil {
+		return err
+	}
+
+	return s.purge(ctx, pinID)
+}
+
+// PurgeExpired はゴミ箱に入ってからretention以上経過した全ユーザーのPinを完全に削除し、削除した件数を返します
+func (s *trashServiceImpl) PurgeExpired(ctx context.Context, retention time.Duration) (int, error) {
+	purged := 0
+	for {
+		ids, err := s.pinRepo.FindExpiredTrashIDs(ctx, retention, purgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to find expired pins: %w", err)
+		}
+
+		for _, id := range ids {
+			if err := s.purge(ctx, id); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purge はPinを物理削除し、写真をストレージから削除します
+// ストレージからの削除に失敗してもデータベースからは参照されないため、ログに記録して処理を続けます
+func (s *trashServiceImpl) purge(ctx context.Context, pinID string) error {
+	keys, err := s.pinRepo.HardDelete(ctx, pinID)
+	if err != nil {
+		return fmt.Errorf("failed to purge pin: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete photo object %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// findOwnDeletedPin はゴミ箱にある自分のPinかを確認します
+func (s *trashServiceImpl) findOwnDeletedPin(ctx context.Context, pinID, userID string) error {
+	pin, err := s.pinRepo.FindDeletedByID(ctx, pinID)
+	if err != nil {
+		return ErrPinNotFound
+	}
+
+	// 所有権の確認
+	if pin.UserID != userID {
+		return ErrUnauthorizedPinAccess
+	}
+
+	return nil
+}
+
+// RunTrashRetention はintervalごとに保持期間を過ぎたゴミ箱のPinを完全に削除します
+// ctxがキャンセルされるまで処理を続けるため、ゴルーチンで実行してください
+func RunTrashRetention(ctx context.Context, trashService TrashService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := trashService.PurgeExpired(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to purge expired pins: %v", err)
+		}
+		if purged > 0 {
+			log.Printf("Purged %d pins from trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrObjectNotFound は指定されたキーのオブジェクトが存在しないエラー
+var ErrObjectNotFound = errors.New("object not found")
+
+// PhotoStorage は写真などのバイナリを保存するストレージのインターフェースを定義します
+// キーは "/" 区切りの相対パスで、英数字と "-", "_", "." のみを使用できます
+type PhotoStorage interface {
+	// Put はオブジェクトを保存します。同じキーのオブジェクトが存在する場合は上書きします
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get はオブジェクトを読み取ります。存在しない場合はErrObjectNotFoundを返します
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はオブジェクトを削除します。存在しない場合もエラーにはなりません
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	// DriverLocal はローカルファイルシステムに保存するドライバー
+	DriverLocal = "local"
+	// DriverS3 はS3互換のオブジェクトストレージに保存するドライバー
+	DriverS3 = "s3"
+)
+
+// Config はストレージの設定
+type Config struct {
+	Driver   string // local または s3
+	LocalDir string // localドライバーの保存先ディレクトリ
+	S3       S3Config
+}
+
+// NewConfig は環境変数から設定を読み込む
+func NewConfig() *Config {
+	return &Config{
+		Driver:   getenv("PHOTO_STORAGE", DriverLocal),
+		LocalDir: getenv("PHOTO_STORAGE_DIR", "./data/photos"),
+		S3: S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getenv("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    os.Getenv("S3_USE_PATH_STYLE") == "true",
+		},
+	}
+}
+
+// New は設定に応じたPhotoStorageを作成します
+func New(config *Config) (PhotoStorage, error) {
+	switch config.Driver {
+	case DriverLocal:
+		return NewLocalStorage(config.LocalDir)
+	case DriverS3:
+		return NewS3Storage(config.S3)
+	default:
+		return nil, fmt.Errorf("unknown photo storage driver: %q", config.Driver)
+	}
+}
+
+// keyPattern はオブジェクトキーに使用できる文字
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// validateKey はオブジェクトキーを検証します
+// ローカルストレージで保存先ディレクトリの外を指すことがないよう "." と ".." の要素は許可しません
+func validateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid object key: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("invalid object key: %q", key)
+		}
+	}
+	return nil
+}
+
+// getenv は環境変数を読み込み、未設定の場合はデフォルト値を返します
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// MVTContentType はMapbox Vector Tileのメディアタイプ
+const MVTContentType = "application/vnd.mapbox-vector-tile"
+
+// TileCacheControl はタイルレスポンスのCache-Control値
+// 認証済みユーザー向けのデータのため共有キャッシュには保存させない
+const TileCacheControl = "private, max-age=60"
+
+// JWKSCacheControl はJWKSレスポンスのCache-Control値
+// 鍵のローテーションでは、新しい鍵を公開してからこの期間が過ぎた後に署名に使用してください
+const JWKSCacheControl = "public, max-age=300"
+
+// ETag はレスポンスボディから強いETagを生成します
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// RespondWithETag はETagとCache-Controlを付けてバイナリレスポンスを返します
+// If-None-MatchがETagと一致する場合は304 Not Modifiedを返します
+func RespondWithETag(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	etag := ETag(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", TileCacheControl)
+
+	if matchesETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// matchesETag はIf-None-Matchヘッダーの値がETagと一致するかを判定します
+func matchesETag(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// EmailVerificationTTL はメールアドレス確認用トークンの有効期間
+	EmailVerificationTTL = 24 * time.Hour
+	// emailVerificationAudience はメールアドレス確認用トークンのaud
+	// アクセストークンとして使用されないよう用途を区別します
+	emailVerificationAudience = "email-verification"
+)
+
+// EmailVerificationClaims はメールアドレス確認用トークンのクレームを表します
+// RegisteredClaims.Subjectに確認するユーザーのIDを設定します
+type EmailVerificationClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// GenerateEmailVerificationToken はユーザーIDとメールアドレスを確認するための署名付きトークンを生成します
+// トークンはメールアドレスが未確認の間のみ使用でき、確認が完了すると再使用できません
+func (ks *KeySet) GenerateEmailVerificationToken(userID, email string) (string, error) {
+	now := time.Now()
+	claims := EmailVerificationClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			Audience:  jwt.ClaimStrings{emailVerificationAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(EmailVerificationTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return ks.sign(claims)
+}
+
+// ValidateEmailVerificationToken はメールアドレス確認用トークンを検証し、クレームを返します
+func (ks *KeySet) ValidateEmailVerificationToken(tokenString string) (*EmailVerificationClaims, error) {
+	claims := &EmailVerificationClaims{}
+	if err := ks.parseClaims(tokenString, claims, jwt.WithAudience(emailVerificationAudience)); err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" || claims.Email == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
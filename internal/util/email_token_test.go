@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailVerificationToken(t *testing.T) {
+	ks, err := NewHMACKeySet("test-secret-key-for-jwt-token-generation-32chars")
+	require.NoError(t, err)
+
+	token, err := ks.GenerateEmailVerificationToken("user-1", "user@example.com")
+	require.NoError(t, err)
+
+	claims, err := ks.ValidateEmailVerificationToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "user@example.com", claims.Email)
+
+	// 確認用トークンはアクセストークンとして使用できない
+	_, err = ks.ValidateToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// アクセストークンは確認用トークンとして使用できない
+	accessToken, err := ks.GenerateToken("user-1", "user@example.com", 0)
+	require.NoError(t, err)
+	_, err = ks.ValidateEmailVerificationToken(accessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = ks.ValidateEmailVerificationToken("invalid-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
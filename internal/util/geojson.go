@@ -0,0 +1,29 @@
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// GeoJSONContentType はGeoJSONのメディアタイプ（RFC 7946）
+const GeoJSONContentType = "application/geo+json"
+
+// WantsGeoJSON はリクエストがGeoJSON形式のレスポンスを要求しているかを判定します
+// ?format=geojson または Accept: application/geo+json の場合にtrueを返します
+func WantsGeoJSON(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get("format"), "geojson") {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), GeoJSONContentType)
+}
+
+// RespondGeoJSON はGeoJSON形式でレスポンスを返します
+func RespondGeoJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", GeoJSONContentType)
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
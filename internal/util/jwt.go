@@ -2,16 +2,18 @@ package util
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // JWTClaims はJWTトークンのクレームを表します
+// RegisteredClaims.ID（jti）はトークンごとに一意で、ログアウト時の失効に使用します
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID     string `json:"user_id"`
+	Email      string `json:"email"`
+	Generation int    `json:"gen"` // 発行時のユーザーのトークン世代（全端末からのログアウトで進む）
 	jwt.RegisteredClaims
 }
 
@@ -29,60 +31,56 @@ var (
 const (
 	// MinSecretLength はJWTシークレットの最小文字数
 	MinSecretLength = 32
+	// AccessTokenTTL はアクセストークンの有効期間
+	// 期限が切れたらリフレッシュトークンで再発行します
+	AccessTokenTTL = 15 * time.Minute
 )
 
-// GenerateToken はユーザーIDとメールアドレスからJWTトークンを生成します
-func GenerateToken(userID, email string) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", ErrMissingSecret
-	}
-	if len(secret) < MinSecretLength {
-		return "", ErrWeakSecret
-	}
-
+// GenerateToken はユーザーIDとメールアドレス、トークン世代からJWTトークンを生成します
+// 署名鍵がRS256またはEdDSAの場合はヘッダーにkidを設定します
+func (ks *KeySet) GenerateToken(userID, email string, generation int) (string, error) {
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:     userID,
+		Email:      email,
+		Generation: generation,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return ks.sign(claims)
 }
 
 // ValidateToken はJWTトークンを検証し、クレームを返します
-func ValidateToken(tokenString string) (*JWTClaims, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, ErrMissingSecret
+// kidに対応する鍵で検証するため、鍵の集合にない鍵で署名されたトークンは受け付けません
+func (ks *KeySet) ValidateToken(tokenString string) (*JWTClaims, error) {
+	claims := &JWTClaims{}
+	if err := ks.parseClaims(tokenString, claims); err != nil {
+		return nil, err
 	}
-	if len(secret) < MinSecretLength {
-		return nil, ErrWeakSecret
+
+	// 用途の異なるトークン（メールアドレス確認用など）はユーザーIDを持たない
+	if claims.UserID == "" {
+		return nil, ErrInvalidToken
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// 署名方式の検証
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(secret), nil
-	})
+	return claims, nil
+}
 
+// parseClaims はトークンの署名と有効期限を検証し、クレームを読み込みます
+func (ks *KeySet) parseClaims(tokenString string, claims jwt.Claims, options ...jwt.ParserOption) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, ks.keyFunc, options...)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, ErrExpiredToken
+			return ErrExpiredToken
 		}
-		return nil, ErrInvalidToken
+		return ErrInvalidToken
 	}
-
-	claims, ok := token.Claims.(*JWTClaims)
-	if !ok || !token.Valid {
-		return nil, ErrInvalidToken
+	if !token.Valid {
+		return ErrInvalidToken
 	}
 
-	return claims, nil
+	return nil
 }
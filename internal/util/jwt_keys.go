@@ -0,0 +1,312 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// AlgRS256 はRSA（SHA-256）による署名アルゴリズム
+	AlgRS256 = "RS256"
+	// AlgEdDSA はEd25519による署名アルゴリズム
+	AlgEdDSA = "EdDSA"
+	// AlgHS256 は共有鍵（HMAC-SHA256）による署名アルゴリズム
+	AlgHS256 = "HS256"
+	// MinRSAKeyBits はRS256で使用するRSA鍵の最小ビット数
+	MinRSAKeyBits = 2048
+	// keyFileExt はディレクトリから読み込む鍵ファイルの拡張子
+	keyFileExt = ".pem"
+)
+
+var (
+	// ErrNoSigningKey は署名に使用できる秘密鍵がないエラーを表します
+	ErrNoSigningKey = errors.New("no private key available for signing")
+	// ErrUnknownKeyID はトークンのkidに対応する鍵がないエラーを表します
+	ErrUnknownKeyID = errors.New("unknown key id")
+)
+
+// SigningKey はkidで識別されるJWTの署名鍵です
+// Privateがnilの鍵は検証のみに使用します（ローテーションで署名に使わなくなった鍵など）
+// HS256の鍵はPrivateとPublicの代わりにSecretを使用します
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+	Secret    []byte
+}
+
+// KeySet はJWTの署名と検証に使用する鍵の集合です
+// 署名には1つの鍵のみを使用し、検証には全ての鍵を使用します
+type KeySet struct {
+	keys    map[string]*SigningKey
+	ids     []string // kidの昇順
+	signing *SigningKey
+}
+
+// NewKeySet は鍵の一覧から鍵の集合を作成します
+// signingKeyIDが空の場合は、秘密鍵を持つ鍵のうちkidが辞書順で最後の鍵で署名します
+func NewKeySet(keys []*SigningKey, signingKeyID string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*SigningKey, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key id is empty")
+		}
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id: %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+		ks.ids = append(ks.ids, key.ID)
+	}
+	sort.Strings(ks.ids)
+
+	if signingKeyID != "" {
+		key, ok := ks.keys[signingKeyID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, signingKeyID)
+		}
+		if key.Private == nil {
+			return nil, fmt.Errorf("%w: key %q has no private key", ErrNoSigningKey, signingKeyID)
+		}
+		ks.signing = key
+		return ks, nil
+	}
+
+	for i := len(ks.ids) - 1; i >= 0; i-- {
+		if key := ks.keys[ks.ids[i]]; key.Private != nil {
+			ks.signing = key
+			return ks, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// LoadKeySet はPEMファイルまたはディレクトリから鍵の集合を読み込みます
+// ディレクトリの場合は直下の*.pemファイルを全て読み込みます。kidはファイル名から拡張子を除いたものです
+// 秘密鍵（PKCS#8、PKCS#1）は署名と検証、公開鍵（PKIX、PKCS#1）は検証のみに使用します
+func LoadKeySet(paths []string, signingKeyID string) (*KeySet, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key path: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(path, "*"+keyFileExt))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list key directory: %w", err)
+		}
+		files = append(files, matches...)
+	}
+
+	keys := make([]*SigningKey, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		key, err := ParseSigningKey(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)), data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeySet(keys, signingKeyID)
+}
+
+// NewHMACKeySet は共有鍵によるHS256の鍵の集合を作成します
+// HS256で署名したトークンにはkidを設定しないため、鍵のkidは空です
+func NewHMACKeySet(secret string) (*KeySet, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	if len(secret) < MinSecretLength {
+		return nil, ErrWeakSecret
+	}
+
+	key := &SigningKey{Algorithm: AlgHS256, Secret: []byte(secret)}
+	return &KeySet{
+		keys:    map[string]*SigningKey{key.ID: key},
+		ids:     []string{key.ID},
+		signing: key,
+	}, nil
+}
+
+// NewKeySetFromEnv は環境変数から鍵の集合を読み込みます
+// JWT_KEYSにはPEMファイルまたはディレクトリをカンマ区切りで指定し、JWT_SIGNING_KEY_IDで署名に使用する鍵を指定できます
+// JWT_KEYSが設定されていない場合はJWT_SECRETによるHS256の鍵の集合を返します
+func NewKeySetFromEnv() (*KeySet, error) {
+	value := strings.TrimSpace(os.Getenv("JWT_KEYS"))
+	if value == "" {
+		return NewHMACKeySet(os.Getenv("JWT_SECRET"))
+	}
+
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return LoadKeySet(paths, strings.TrimSpace(os.Getenv("JWT_SIGNING_KEY_ID")))
+}
+
+// ParseSigningKey はPEM形式の秘密鍵または公開鍵を解析します
+// RSA鍵はRS256、Ed25519鍵はEdDSAで使用します
+func ParseSigningKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+
+	key := &SigningKey{ID: id}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.Private = signer
+		key.Public = signer.Public()
+	} else {
+		key.Public = parsed
+	}
+
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < MinRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", MinRSAKeyBits)
+		}
+		key.Algorithm = AlgRS256
+	case ed25519.PublicKey:
+		key.Algorithm = AlgEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", key.Public)
+	}
+
+	return key, nil
+}
+
+// SigningKeyID は署名に使用する鍵のkidを返します。HS256の場合は空です
+func (ks *KeySet) SigningKeyID() string {
+	return ks.signing.ID
+}
+
+// SigningAlgorithm は署名に使用する鍵のアルゴリズムを返します
+func (ks *KeySet) SigningAlgorithm() string {
+	return ks.signing.Algorithm
+}
+
+// sign はクレームを署名鍵で署名し、ヘッダーにkidを設定したトークンを返します
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(signingMethod(ks.signing.Algorithm), claims)
+	if ks.signing.Algorithm == AlgHS256 {
+		return token.SignedString(ks.signing.Secret)
+	}
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.Private)
+}
+
+// keyFunc はトークンのkidに対応する検証用の公開鍵を返します
+// 鍵のアルゴリズムとトークンのalgが一致しない場合は検証に失敗します
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, ErrInvalidToken
+	}
+	if key.Algorithm == AlgHS256 {
+		return key.Secret, nil
+	}
+	return key.Public, nil
+}
+
+// signingMethod はアルゴリズム名に対応する署名方式を返します
+func signingMethod(algorithm string) jwt.SigningMethod {
+	switch algorithm {
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA
+	case AlgHS256:
+		return jwt.SigningMethodHS256
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JWK はJSON Web Key（RFC 7517）の公開鍵を表します
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSAの法
+	E         string `json:"e,omitempty"`   // RSAの公開指数
+	Curve     string `json:"crv,omitempty"` // Ed25519の曲線名
+	X         string `json:"x,omitempty"`   // Ed25519の公開鍵
+}
+
+// JWKS はJSON Web Key Setを表します
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS は検証に使用する全ての鍵の公開鍵をkidの昇順で返します
+// ローテーションで署名に使わなくなった鍵も、鍵の集合から削除するまで含まれます
+// HS256の共有鍵は公開できないため含まれません
+func (ks *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: make([]JWK, 0, len(ks.ids))}
+	for _, id := range ks.ids {
+		key := ks.keys[id]
+		if key.Algorithm == AlgHS256 {
+			continue
+		}
+		jwk := JWK{Use: "sig", KeyID: key.ID, Algorithm: key.Algorithm}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM は鍵をPEM形式でファイルに書き込みます
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, MinRSAKeyBits)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2026-01.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2026-02.pem"), "PRIVATE KEY", der)
+
+	// 拡張子が.pemでないファイルは読み込まない
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("keys"), 0o600))
+
+	t.Run("成功: kidが最後の秘密鍵で署名する", func(t *testing.T) {
+		ks, err := LoadKeySet([]string{dir}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-02", ks.SigningKeyID())
+
+		jwks := ks.JWKS()
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, JWK{KeyType: "RSA", Use: "sig", KeyID: "2026-01", Algorithm: AlgRS256, N: jwks.Keys[0].N, E: "AQAB"}, jwks.Keys[0])
+		assert.NotEmpty(t, jwks.Keys[0].N)
+		assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+		assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+		assert.Equal(t, AlgEdDSA, jwks.Keys[1].Algorithm)
+		assert.NotEmpty(t, jwks.Keys[1].X)
+	})
+
+	t.Run("成功: 署名に使用する鍵を指定", func(t *testing.T) {
+		ks, err := LoadKeySet([]string{dir}, "2026-01")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-01", ks.SigningKeyID())
+	})
+
+	t.Run("エラー: 不正な鍵", func(t *testing.T) {
+		_, err := LoadKeySet([]string{dir}, "unknown")
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+
+		// 公開鍵のみの場合は署名できない
+		publicDir := t.TempDir()
+		der, err := x509.MarshalPKIXPublicKey(edKey.Public())
+		require.NoError(t, err)
+		writePEM(t, filepath.Join(publicDir, "old.pem"), "PUBLIC KEY", der)
+		_, err = LoadKeySet([]string{publicDir}, "")
+		assert.ErrorIs(t, err, ErrNoSigningKey)
+
+		// 短すぎるRSA鍵
+		weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		_, err = ParseSigningKey("weak", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weakKey)}))
+		assert.Error(t, err)
+
+		_, err = ParseSigningKey("invalid", []byte("not a key"))
+		assert.Error(t, err)
+	})
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, MinRSAKeyBits)
+	require.NoError(t, err)
+
+	// HS256で発行されたトークン
+	hmac, err := NewHMACKeySet("test-secret-key-for-jwt-token-generation-32chars")
+	require.NoError(t, err)
+	hmacToken, err := hmac.GenerateToken("user-1", "user@example.com", 0)
+	require.NoError(t, err)
+
+	// 旧鍵で署名
+	before, err := NewKeySet([]*SigningKey{{ID: "old", Algorithm: AlgEdDSA, Private: oldKey, Public: oldKey.Public()}}, "")
+	require.NoError(t, err)
+	oldToken, err := before.GenerateToken("user-1", "user@example.com", 0)
+	require.NoError(t, err)
+
+	// 新鍵で署名し、旧鍵は検証のみに使用する
+	after, err := NewKeySet([]*SigningKey{
+		{ID: "old", Algorithm: AlgEdDSA, Public: oldKey.Public()},
+		{ID: "new", Algorithm: AlgRS256, Private: newKey, Public: newKey.Public()},
+	}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "new", after.SigningKeyID())
+
+	newToken, err := after.GenerateToken("user-1", "user@example.com", 0)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "new", parsed.Header["kid"])
+	assert.Equal(t, AlgRS256, parsed.Method.Alg())
+
+	// 旧鍵で署名されたトークンも期限までは検証できる
+	for _, token := range []string{oldToken, newToken} {
+		claims, err := after.ValidateToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.UserID)
+	}
+
+	// 鍵の集合から削除した鍵のトークンは検証できない
+	removed, err := NewKeySet([]*SigningKey{{ID: "new", Algorithm: AlgRS256, Private: newKey, Public: newKey.Public()}}, "")
+	require.NoError(t, err)
+	_, err = removed.ValidateToken(oldToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// RS256・EdDSAの鍵の集合ではHS256のトークンは受け付けない
+	_, err = removed.ValidateToken(hmacToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// HS256の鍵の集合では共有鍵で署名したトークンのみ受け付ける
+	claims, err := hmac.ValidateToken(hmacToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	_, err = hmac.ValidateToken(newToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewHMACKeySet(t *testing.T) {
+	_, err := NewHMACKeySet("")
+	assert.ErrorIs(t, err, ErrMissingSecret)
+
+	_, err = NewHMACKeySet("short")
+	assert.ErrorIs(t, err, ErrWeakSecret)
+
+	ks, err := NewHMACKeySet("test-secret-key-for-jwt-token-generation-32chars")
+	require.NoError(t, err)
+	assert.Equal(t, AlgHS256, ks.SigningAlgorithm())
+	assert.Empty(t, ks.JWKS().Keys)
+
+	// HS256のトークンにはkidを設定しない
+	token, err := ks.GenerateToken("user-1", "user@example.com", 0)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	assert.NotContains(t, parsed.Header, "kid")
+	assert.Equal(t, AlgHS256, parsed.Method.Alg())
+}
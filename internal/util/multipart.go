@@ -0,0 +1,35 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// ReadMultipartFile はmultipart/form-dataのリクエストからnameのパートを探し、fnで読み取ります
+// ファイル全体をメモリやディスクに展開せずに逐次処理できます。パートはfnの終了後に閉じます
+func ReadMultipartFile(r *http.Request, name string, fn func(part *multipart.Part) error) error {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return errors.New("request must be multipart/form-data")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return fmt.Errorf("%s is required", name)
+		}
+		if err != nil {
+			return err
+		}
+		if part.FormName() != name {
+			part.Close()
+			continue
+		}
+		defer part.Close()
+
+		return fn(part)
+	}
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseFloatQuery は必須のクエリパラメータを浮動小数点数としてパースします
+func ParseFloatQuery(r *http.Request, name string) (float64, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", name)
+	}
+
+	return value, nil
+}
+
+// ParseIntQuery は任意のクエリパラメータを整数としてパースします
+// パラメータが指定されていない場合はdefaultValueを返します
+func ParseIntQuery(r *http.Request, name string, defaultValue int) (int, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+
+	return value, nil
+}
+
+// ParseBoolQuery は任意のクエリパラメータを真偽値としてパースします
+// パラメータが指定されていない場合はnilを返します
+func ParseBoolQuery(r *http.Request, name string) (*bool, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", name)
+	}
+
+	return &value, nil
+}
+
+// ParseTimeQuery は任意のクエリパラメータをRFC 3339形式の日時としてパースします
+// パラメータが指定されていない場合はnilを返します
+func ParseTimeQuery(r *http.Request, name string) (*time.Time, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+	}
+
+	return &value, nil
+}
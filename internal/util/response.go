@@ -30,6 +30,17 @@ const (
 	ErrCodeConflict       = "CONFLICT"
 	ErrCodeInternalServer = "INTERNAL_SERVER_ERROR"
 	ErrCodeDatabaseError  = "DATABASE_ERROR"
+
+	// Connectの整合性エラー
+	ErrCodeSelfConnect      = "SELF_CONNECT"
+	ErrCodeDuplicateConnect = "DUPLICATE_CONNECT"
+	ErrCodePinNotOwned      = "PIN_NOT_OWNED"
+
+	// Connectの経路探索エラー
+	ErrCodeNoPath = "NO_PATH"
+
+	// メールアドレスが未確認のユーザーには許可されていない操作のエラー
+	ErrCodeEmailNotVerified = "EMAIL_NOT_VERIFIED"
 )
 
 // RespondJSON はJSON形式で成功レスポンスを返します
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// 実行環境にタイムゾーンデータが無い場合（alpineイメージなど）に備えて埋め込む
+	_ "time/tzdata"
+)
+
+// ValidateTimezone はIANA形式のタイムゾーン名を検証します
+func ValidateTimezone(name string) error {
+	if _, err := LoadTimezone(name); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LoadTimezone はIANA形式のタイムゾーン名からLocationを取得します
+// サーバーの設定に依存するLocalは受け付けません
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, errors.New("timezone must be an IANA time zone name such as Asia/Tokyo")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("timezone: unknown time zone %q", name)
+	}
+	return loc, nil
+}
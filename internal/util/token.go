@@ -0,0 +1,29 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	// RandomTokenBytes はランダムトークンの長さ（バイト）
+	RandomTokenBytes = 32
+)
+
+// GenerateRandomToken は推測困難なURLセーフのランダムトークンを生成します
+func GenerateRandomToken() (string, error) {
+	bytes := make([]byte, RandomTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken はトークンをデータベースに保存するためのSHA-256ハッシュ（16進数）に変換します
+// ランダムトークンは十分なエントロピーを持つため、ソルトやストレッチングは不要です
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
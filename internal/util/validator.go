@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/higawarikaisendonn/unchingspot-backend/internal/openinghours"
 )
 
 var (
@@ -105,3 +108,42 @@ func ValidateUUID(id string) error {
 	}
 	return nil
 }
+
+// ValidateFee は有料かどうかと利用料金の組み合わせを検証します
+// 利用料金は有料（paid=true）の場合のみ、0以上の値を指定できます
+func ValidateFee(paid *bool, feeAmount *int) error {
+	if feeAmount == nil {
+		return nil
+	}
+	if *feeAmount < 0 {
+		return errors.New("fee_amount must not be negative")
+	}
+	if paid == nil || !*paid {
+		return errors.New("fee_amount requires paid to be true")
+	}
+	return nil
+}
+
+// ValidateOpeningHours はOpenStreetMapのopening_hours形式の営業時間を検証します
+func ValidateOpeningHours(openingHours string) error {
+	if _, err := openinghours.Parse(openingHours); err != nil {
+		return fmt.Errorf("opening_hours: %v", err)
+	}
+	return nil
+}
+
+// ValidateScore は1〜maxの段階評価のスコアを検証します
+func ValidateScore(score, max int, fieldName string) error {
+	if score < 1 || score > max {
+		return fmt.Errorf("%s must be between 1 and %d", fieldName, max)
+	}
+	return nil
+}
+
+// ValidateMaxLength は文字列の文字数（バイト数ではない）の上限を検証します
+func ValidateMaxLength(value string, max int, fieldName string) error {
+	if utf8.RuneCountInString(value) > max {
+		return fmt.Errorf("%s must be at most %d characters", fieldName, max)
+	}
+	return nil
+}
@@ -0,0 +1,2 @@
+-- Drop geography expression index
+DROP INDEX IF EXISTS idx_pins_location_geography;
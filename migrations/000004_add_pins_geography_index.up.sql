@@ -0,0 +1,2 @@
+-- Create geography expression index for distance queries (ST_DWithin / ST_Distance)
+CREATE INDEX idx_pins_location_geography ON pins USING GIST((location::geography));
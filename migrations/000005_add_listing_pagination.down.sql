@@ -0,0 +1,6 @@
+-- Drop keyset pagination indexes
+DROP INDEX IF EXISTS idx_pins_user_created_at;
+DROP INDEX IF EXISTS idx_connect_user_created_at;
+
+-- Drop created_at from connect
+ALTER TABLE connect DROP COLUMN IF EXISTS created_at;
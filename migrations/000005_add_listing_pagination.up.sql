@@ -0,0 +1,7 @@
+-- Add created_at to connect for stable keyset pagination
+-- 既存の行はマイグレーション実行時刻となり、同時刻の行はidで順序付けされる
+ALTER TABLE connect ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Create indexes for keyset pagination on (created_at, id)
+CREATE INDEX idx_connect_user_created_at ON connect(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_pins_user_created_at ON pins(user_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- Drop public pin feed index
+DROP INDEX IF EXISTS idx_pins_public_created_at;
+
+-- Drop visibility and share link token from pins
+ALTER TABLE pins DROP COLUMN IF EXISTS share_token;
+ALTER TABLE pins DROP COLUMN IF EXISTS visibility;
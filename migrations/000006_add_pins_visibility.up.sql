@@ -0,0 +1,9 @@
+-- Add visibility and share link token to pins
+-- 既存のPinは所有者のみが閲覧できるprivateとする
+ALTER TABLE pins ADD COLUMN visibility TEXT NOT NULL DEFAULT 'private'
+    CHECK (visibility IN ('private', 'shared-by-link', 'public'));
+ALTER TABLE pins ADD COLUMN share_token TEXT UNIQUE;
+
+-- Create index for the public pin feed
+CREATE INDEX idx_pins_public_created_at ON pins(created_at DESC, id DESC)
+    WHERE visibility = 'public' AND deleted_at IS NULL;
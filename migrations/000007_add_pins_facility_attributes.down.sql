@@ -0,0 +1,9 @@
+-- Drop toilet facility attributes from pins
+ALTER TABLE pins DROP CONSTRAINT IF EXISTS chk_pins_fee_amount_paid;
+ALTER TABLE pins DROP COLUMN IF EXISTS opening_hours;
+ALTER TABLE pins DROP COLUMN IF EXISTS fee_amount;
+ALTER TABLE pins DROP COLUMN IF EXISTS paid;
+ALTER TABLE pins DROP COLUMN IF EXISTS washlet;
+ALTER TABLE pins DROP COLUMN IF EXISTS gender_neutral;
+ALTER TABLE pins DROP COLUMN IF EXISTS baby_changing;
+ALTER TABLE pins DROP COLUMN IF EXISTS wheelchair_accessible;
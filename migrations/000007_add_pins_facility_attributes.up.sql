@@ -0,0 +1,12 @@
+-- Add toilet facility attributes to pins
+-- 真偽値の属性はNULLを「不明」として扱う
+ALTER TABLE pins ADD COLUMN wheelchair_accessible BOOLEAN;
+ALTER TABLE pins ADD COLUMN baby_changing BOOLEAN;
+ALTER TABLE pins ADD COLUMN gender_neutral BOOLEAN;
+ALTER TABLE pins ADD COLUMN washlet BOOLEAN;
+ALTER TABLE pins ADD COLUMN paid BOOLEAN;
+ALTER TABLE pins ADD COLUMN fee_amount INTEGER CHECK (fee_amount >= 0);
+ALTER TABLE pins ADD COLUMN opening_hours TEXT;
+
+-- 料金は有料のPinにのみ設定できる
+ALTER TABLE pins ADD CONSTRAINT chk_pins_fee_amount_paid CHECK (fee_amount IS NULL OR paid);
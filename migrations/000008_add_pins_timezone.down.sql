@@ -0,0 +1,2 @@
+-- Drop timezone from pins
+ALTER TABLE pins DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,3 @@
+-- Add timezone to pins
+-- 営業時間を評価するタイムゾーン（IANA名）。NULLの場合は座標から推定する
+ALTER TABLE pins ADD COLUMN timezone TEXT;
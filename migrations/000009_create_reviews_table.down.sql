@@ -0,0 +1,2 @@
+-- Drop reviews table
+DROP TABLE IF EXISTS reviews;
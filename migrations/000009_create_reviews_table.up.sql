@@ -0,0 +1,20 @@
+-- Create reviews table
+-- 各スコアは1〜5の5段階評価（crowdingは5が空いている）
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pin_id UUID NOT NULL REFERENCES pins(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cleanliness SMALLINT NOT NULL CHECK (cleanliness BETWEEN 1 AND 5),
+    crowding SMALLINT NOT NULL CHECK (crowding BETWEEN 1 AND 5),
+    paper_availability SMALLINT NOT NULL CHECK (paper_availability BETWEEN 1 AND 5),
+    comment TEXT NOT NULL DEFAULT '' CHECK (char_length(comment) <= 500),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- 1人のユーザーは1つのPinに1件のみレビューできる（削除済みのレビューは除く）
+CREATE UNIQUE INDEX idx_reviews_pin_user ON reviews(pin_id, user_id) WHERE deleted_at IS NULL;
+
+-- Create index for listing reviews of a pin
+CREATE INDEX idx_reviews_pin_created_at ON reviews(pin_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
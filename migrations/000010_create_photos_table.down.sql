@@ -0,0 +1,2 @@
+-- Drop photos table
+DROP TABLE IF EXISTS photos;
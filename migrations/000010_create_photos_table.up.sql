@@ -0,0 +1,18 @@
+-- Create photos table
+-- 画像本体とサムネイルはPhotoStorageに保存し、このテーブルにはそのキーを保持する
+CREATE TABLE photos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pin_id UUID NOT NULL REFERENCES pins(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL CHECK (width > 0),
+    height INTEGER NOT NULL CHECK (height > 0),
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- Create index for listing photos of a pin
+CREATE INDEX idx_photos_pin_created_at ON photos(pin_id, created_at, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Drop pin_revisions table
+DROP TABLE IF EXISTS pin_revisions;
@@ -0,0 +1,16 @@
+-- Create pin_revisions table
+-- Pinの更新ごとに、更新前後の名前と位置、更新したユーザーを記録する
+CREATE TABLE pin_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pin_id UUID NOT NULL REFERENCES pins(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    editor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_name TEXT NOT NULL,
+    new_name TEXT NOT NULL,
+    old_location GEOMETRY(Point, 4326) NOT NULL,
+    new_location GEOMETRY(Point, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- リビジョン番号はPinごとに1から採番する
+CREATE UNIQUE INDEX idx_pin_revisions_pin_revision ON pin_revisions(pin_id, revision);
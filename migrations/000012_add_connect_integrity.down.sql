@@ -0,0 +1,4 @@
+-- Drop integrity constraints from connect
+-- 削除された重複データは復元されない
+DROP INDEX IF EXISTS idx_connect_user_pin_pair;
+ALTER TABLE connect DROP CONSTRAINT IF EXISTS connect_distinct_pins;
@@ -0,0 +1,19 @@
+-- Add integrity constraints to connect
+-- 制約を追加する前に、既存の自己ループと重複した接続を削除する
+
+-- 同じPin同士の接続を削除
+DELETE FROM connect WHERE pins_id_1 = pins_id_2;
+
+-- 同じユーザーの同じPinの組み合わせ（順序は問わない）の接続は最も古いもののみ残す
+DELETE FROM connect AS c
+USING connect AS d
+WHERE c.user_id = d.user_id
+    AND LEAST(c.pins_id_1, c.pins_id_2) = LEAST(d.pins_id_1, d.pins_id_2)
+    AND GREATEST(c.pins_id_1, c.pins_id_2) = GREATEST(d.pins_id_1, d.pins_id_2)
+    AND (d.created_at, d.id) < (c.created_at, c.id);
+
+-- 同じPin同士は接続できない
+ALTER TABLE connect ADD CONSTRAINT connect_distinct_pins CHECK (pins_id_1 <> pins_id_2);
+
+-- 1人のユーザーは同じPinの組み合わせを1つのみ接続できる
+CREATE UNIQUE INDEX idx_connect_user_pin_pair ON connect(user_id, LEAST(pins_id_1, pins_id_2), GREATEST(pins_id_1, pins_id_2));
@@ -0,0 +1,2 @@
+-- Drop areas table
+DROP TABLE IF EXISTS areas;
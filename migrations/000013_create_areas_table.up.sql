@@ -0,0 +1,16 @@
+-- Create areas table
+-- 順序付きのPinの環で囲まれたポリゴン。作成時点のPinの位置で作成し、pin_idsに環の順序を保持する
+CREATE TABLE areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    pin_ids UUID[] NOT NULL CHECK (cardinality(pin_ids) >= 3),
+    polygon GEOMETRY(Polygon, 4326) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for listing areas of a user
+CREATE INDEX idx_areas_user_created_at ON areas(user_id, created_at DESC, id DESC);
+
+-- Create spatial index for point-in-area queries
+CREATE INDEX idx_areas_polygon ON areas USING GIST(polygon);
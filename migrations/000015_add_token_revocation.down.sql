@@ -0,0 +1,3 @@
+-- Remove token revocation
+DROP TABLE IF EXISTS revoked_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS token_generation;
@@ -0,0 +1,13 @@
+-- Add token revocation
+-- token_generationを進めると、それより前の世代で発行された全てのアクセストークンが失効する
+ALTER TABLE users ADD COLUMN token_generation INTEGER NOT NULL DEFAULT 0;
+
+-- ログアウトで失効させたアクセストークン。トークンの有効期限（expires_at）を過ぎた行は削除してよい
+CREATE TABLE revoked_tokens (
+    jti TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Create index for deleting expired revocations
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
@@ -0,0 +1,3 @@
+-- Remove email verification
+ALTER TABLE users DROP COLUMN IF EXISTS verification_mail_failed_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
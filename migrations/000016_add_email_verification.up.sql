@@ -0,0 +1,10 @@
+-- Add email verification
+-- メールアドレスの確認が完了した日時。NULLの場合は未確認で、Pinを公開できない
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+-- 確認機能の導入前に登録したユーザーは確認済みとして扱う
+-- 既存の公開Pinはそのまま公開され、公開フィードにも引き続き表示される
+UPDATE users SET email_verified_at = created_at;
+
+-- 確認メールの送信に最後に失敗した日時。NULLでない場合は再送信が必要
+ALTER TABLE users ADD COLUMN verification_mail_failed_at TIMESTAMP;
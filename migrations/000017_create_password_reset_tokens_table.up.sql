@@ -0,0 +1,13 @@
+-- Create password_reset_tokens table
+-- パスワード再設定用トークンはSHA-256ハッシュのみを保存する。used_atが設定されたトークンは使用できない
+CREATE TABLE password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for invalidating all tokens of a user
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
@@ -0,0 +1,2 @@
+-- Allow pins with opening hours but without timezone
+ALTER TABLE pins DROP CONSTRAINT IF EXISTS pins_opening_hours_timezone;
@@ -0,0 +1,6 @@
+-- Require timezone for pins with opening hours
+-- これまでタイムゾーンが未設定のPinはAsia/Tokyo（クライアントが指定しない場合の既定値）で評価していたため、その値を保存する
+UPDATE pins SET timezone = 'Asia/Tokyo' WHERE opening_hours IS NOT NULL AND timezone IS NULL;
+
+-- 営業時間はPinの現地時間で評価するため、営業時間を持つPinにはタイムゾーンを必須とする
+ALTER TABLE pins ADD CONSTRAINT pins_opening_hours_timezone CHECK (opening_hours IS NULL OR timezone IS NOT NULL);